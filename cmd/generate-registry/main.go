@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+// Generates <skills-dir>/registry.json from the SKILL.md files, bundles and
+// detection rules under it (default: skills)
+func main() {
+	skillsDir := "skills"
+	if len(os.Args) > 1 {
+		skillsDir = os.Args[1]
+	}
+	output := filepath.Join(skillsDir, registry.IndexFileName)
+
+	fmt.Printf("Scanning skills in %s...\n", skillsDir)
+	index, err := registry.GenerateIndex(skillsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	for _, s := range index.Skills {
+		fmt.Printf("  Found: %s/%s\n", s.Stack, s.Name)
+	}
+	for _, b := range index.Bundles {
+		fmt.Printf("  Bundle: %s\n", b.Name)
+	}
+	for _, r := range index.Detect {
+		fmt.Printf("  Detect: %s\n", r.Name)
+	}
+
+	if err := registry.WriteIndex(output, index); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("\nGenerated %s with %d skill(s), %d bundle(s) and %d detection rule(s)\n", output, len(index.Skills), len(index.Bundles), len(index.Detect))
+}
@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/frontmatter"
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/markdown"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/cuongtl1992/vibe-skills/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+var browseCmd = &cobra.Command{
+	Use:   "browse",
+	Short: "Browse, preview and install skills interactively",
+	Long: `Open an interactive picker over the registry: type to search, ↑/↓ to
+move, space to select (on a stack header: the whole stack), ←/→ to fold
+stacks and enter to install the selection. Installed and outdated skills are
+marked, and the SKILL.md under the cursor is previewed on the right.
+
+Selected skills that are already installed are updated. Afterwards you are
+asked whether to add the selection to .vibe-skills.yaml (skip with --no-save).
+
+Without a terminal the skills are listed and picked by number or name.
+
+Examples:
+  vibe-skills browse
+  vibe-skills browse --global
+  vibe-skills install -i`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{dryRunAnnotation: dryRunSupported},
+	RunE:        runBrowse,
+}
+
+func init() {
+	browseCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Install to ~/.claude/skills for all projects")
+	browseCmd.Flags().BoolVar(&installNoSave, "no-save", false, "Don't offer to add the selection to the config")
+	addTargetFlag(browseCmd)
+}
+
+func runBrowse(cmd *cobra.Command, args []string) error {
+	cwd, err := scopeDir()
+	if err != nil {
+		return err
+	}
+
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	inst, err := newInstaller(reg, cwd)
+	if err != nil {
+		return err
+	}
+
+	skills, err := reg.List()
+	if err != nil {
+		return fmt.Errorf("failed to list skills: %w", err)
+	}
+	if len(skills) == 0 {
+		fmt.Println("No skills available.")
+		return nil
+	}
+
+	cmd.SilenceUsage = true
+
+	items := browseItems(inst, skills)
+	selected, err := pickSkills(items, skillPreview(reg, skills))
+	if errors.Is(err, tui.ErrCanceled) {
+		fmt.Println("Canceled.")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		fmt.Println("Nothing selected.")
+		return nil
+	}
+
+	var done, names []string
+	var errs []error
+	for _, it := range selected {
+		if inst.IsInstalled(it.Name) {
+			_, err = inst.UpdateSkill(it.Name)
+		} else {
+			err = inst.Install(it.ID())
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", it.Name, err))
+			continue
+		}
+		done = append(done, it.Name)
+		names = append(names, it.ID())
+	}
+
+	if flagDryRun {
+		return reportDryRun(inst, errs)
+	}
+
+	fmt.Printf("Installed %d skill(s):\n", len(done))
+	for _, name := range done {
+		fmt.Printf("  ✓ %s\n", name)
+	}
+	for _, err := range errs {
+		fmt.Printf("  ✗ %s\n", err)
+	}
+
+	if !installNoSave && len(names) > 0 {
+		ok, err := confirm(fmt.Sprintf("\nSave to %s?", configName()))
+		if err != nil {
+			return err
+		}
+		if ok {
+			if _, err := saveInstalled(cwd, names, nil); err != nil {
+				return fmt.Errorf("failed to update %s: %w", configName(), err)
+			}
+			fmt.Printf("Saved to %s\n", configName())
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("some skills failed to install")
+	}
+	return nil
+}
+
+// browseItems marks installed skills, and outdated ones when the registry
+// can tell without an error
+func browseItems(inst *installer.Installer, skills []registry.Skill) []tui.Item {
+	items := make([]tui.Item, len(skills))
+	for i, s := range skills {
+		items[i] = tui.Item{Name: s.Name, Stack: s.Stack, Description: s.Description}
+		if !inst.IsInstalled(s.Name) {
+			continue
+		}
+		items[i].Installed = true
+		if inst.IsLinked(s.Name) {
+			continue
+		}
+		if u, err := inst.CheckUpdate(s.Name); err == nil {
+			items[i].Outdated = u.Outdated
+		}
+	}
+	return items
+}
+
+// skillPreview returns a cached, rendered preview of each skill's SKILL.md
+func skillPreview(reg *registry.GitHubRegistry, skills []registry.Skill) func(tui.Item) string {
+	cache := make(map[string]string)
+	return func(it tui.Item) string {
+		if text, ok := cache[it.ID()]; ok {
+			return text
+		}
+
+		text := it.Description
+		for _, s := range skills {
+			if s.Stack != it.Stack || s.Name != it.Name {
+				continue
+			}
+			content, err := reg.GetContent(&s)
+			if err != nil {
+				text += "\n\n(preview unavailable: " + err.Error() + ")"
+				break
+			}
+			// The picker wraps the preview itself and draws it without color
+			text = strings.TrimSpace(markdown.Render(frontmatter.Strip(content), 0, false))
+		}
+		cache[it.ID()] = text
+		return text
+	}
+}
+
+// pickSkills opens the interactive picker, or falls back to a numbered
+// prompt when there is no terminal
+func pickSkills(items []tui.Item, preview func(tui.Item) string) ([]tui.Item, error) {
+	if isInteractive() && isTerminal() {
+		selected, err := tui.NewPicker(items, preview).Run()
+		if !errors.Is(err, tui.ErrNoTerminal) {
+			return selected, err
+		}
+	}
+	return promptSkills(items)
+}
+
+// promptSkills lists the skills with numbers and reads a selection of
+// numbers or names
+func promptSkills(items []tui.Item) ([]tui.Item, error) {
+	picker := tui.NewPicker(items, nil)
+	ordered := picker.Items()
+
+	stack := ""
+	for i, it := range ordered {
+		if it.Stack != stack {
+			stack = it.Stack
+			fmt.Printf("\n%s:\n", strings.ToUpper(stack))
+		}
+		badge := ""
+		switch {
+		case it.Outdated:
+			badge = " [outdated]"
+		case it.Installed:
+			badge = " [installed]"
+		}
+		fmt.Printf("  %3d) %-25s %s%s\n", i+1, it.Name, it.Description, badge)
+	}
+
+	answer, err := prompt("\nSelect skills by number or name (e.g. 1,3 or code-reviewer), blank to cancel:")
+	if err != nil {
+		return nil, err
+	}
+	if answer == "" {
+		return nil, tui.ErrCanceled
+	}
+
+	var selected []tui.Item
+	seen := make(map[string]bool)
+	for _, token := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+		match := -1
+		if n, err := strconv.Atoi(token); err == nil && n >= 1 && n <= len(ordered) {
+			match = n - 1
+		} else {
+			for i, it := range ordered {
+				if token == it.Name || token == it.ID() {
+					match = i
+					break
+				}
+			}
+		}
+		if match < 0 {
+			return nil, fmt.Errorf("no skill %q in the list", token)
+		}
+		if it := ordered[match]; !seen[it.ID()] {
+			seen[it.ID()] = true
+			selected = append(selected, it)
+		}
+	}
+	return selected, nil
+}
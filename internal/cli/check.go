@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/spf13/cobra"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Verify installed skills match the config (for CI)",
+	Long: `Verify that .claude/skills matches the skills declared in .vibe-skills.yaml
+and the versions recorded in .claude/skills/.vibe-skills-manifest.json.
+Nothing is written.
+
+The manifest is the lockfile: commit it with the skills. It records the ref,
+commit, version and file hashes each skill was installed from, and check
+holds them against the ref and version pins in the config and the files on
+disk.
+
+Reported problems:
+  missing     Declared or recorded in the manifest, but not installed
+  extra       Installed but not declared
+  modified    Files differ from what was installed
+  untracked   Installed without a manifest entry
+  linked      Linked from a local directory (not reproducible)
+  stale       The registry has a newer version, or the manifest does not
+              match a ref, commit or version pinned in the config
+  unverified  Could not be compared with the registry
+
+Exits with status 1 when any problem is found. With --offline the registry
+index cached by a previous run is used and the network is never touched.
+
+Examples:
+  vibe-skills check
+  vibe-skills check --offline
+  vibe-skills check --json`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{dryRunAnnotation: dryRunReadOnly, outputAnnotation: outputSupported},
+	RunE:        runCheck,
+}
+
+func init() {
+	checkCmd.Flags().BoolVar(&flagJSON, "json", false, "Output problems as JSON (same as --output json)")
+	checkCmd.Flags().BoolVar(&flagOffline, "offline", false, "Use the cached registry index only")
+	checkCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Check ~/.claude/skills against the global config")
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	cwd, err := scopeDir()
+	if err != nil {
+		return err
+	}
+
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	declared, err := declaredSkills(cwd, reg)
+	if err != nil {
+		return err
+	}
+
+	inst, err := newInstaller(reg, cwd)
+	if err != nil {
+		return err
+	}
+
+	cmd.SilenceUsage = true
+
+	problems, err := inst.Check(declared)
+	if err != nil {
+		return err
+	}
+
+	if machineOutput() {
+		if problems == nil {
+			problems = []installer.Problem{}
+		}
+		if err := writeOutput(problems); err != nil {
+			return err
+		}
+	} else {
+		printCheck(problems)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problem(s) found", len(problems))
+	}
+	return nil
+}
+
+func printCheck(problems []installer.Problem) {
+	if len(problems) == 0 {
+		fmt.Println("✓ Skills match the config.")
+		return
+	}
+
+	nameW := len("SKILL")
+	for _, p := range problems {
+		nameW = max(nameW, len(p.Skill))
+	}
+	for _, p := range problems {
+		fmt.Printf("  ✗ %-*s  %-10s  %s\n", nameW, p.Skill, p.Kind, p.Detail)
+	}
+}
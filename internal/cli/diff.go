@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/diff"
+	"github.com/cuongtl1992/vibe-skills/internal/fuzzy"
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffFrom    string
+	diffTo      string
+	diffStat    bool
+	diffNoColor bool
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <skill-name>",
+	Short: "Show what an update would change in a skill",
+	Long: `Show a unified diff between an installed skill and what the registry
+would install now, or between two registry refs.
+
+By default the installed copy is compared with the skill's recorded source at
+its current ref, with the project's overlay applied. --from and --to replace
+either side with a registry ref; with both, nothing on disk is read.
+
+Examples:
+  vibe-skills diff code-reviewer
+  vibe-skills diff code-reviewer --stat
+  vibe-skills diff code-reviewer --to develop
+  vibe-skills diff code-reviewer --from v1.2 --to main
+  vibe-skills diff code-reviewer --json`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: dryRunReadOnly, outputAnnotation: outputSupported},
+	RunE:        runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFrom, "from", "", "Registry ref to diff from (default: the installed copy)")
+	diffCmd.Flags().StringVar(&diffTo, "to", "", "Registry ref to diff to (default: what update would install)")
+	diffCmd.Flags().BoolVar(&diffStat, "stat", false, "Show only the per-file summary")
+	diffCmd.Flags().BoolVar(&flagJSON, "json", false, "Output results as JSON (same as --output json)")
+	diffCmd.Flags().BoolVar(&diffNoColor, "no-color", false, "Disable colored output")
+	diffCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Use the skill installed in ~/.claude/skills")
+}
+
+// diffReport is the JSON form of a skill diff
+type diffReport struct {
+	Skill string     `json:"skill"`
+	From  string     `json:"from"`
+	To    string     `json:"to"`
+	Files []diffFile `json:"files"`
+}
+
+type diffFile struct {
+	diff.FileChange
+	Diff string `json:"diff,omitempty"`
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cwd, err := scopeDir()
+	if err != nil {
+		return err
+	}
+
+	reg, err := getRegistry()
+	if err != nil {
+		return err
+	}
+
+	inst, err := newInstaller(reg, cwd)
+	if err != nil {
+		return err
+	}
+
+	cmd.SilenceUsage = true
+
+	from, fromLabel, err := diffSide(inst, name, diffFrom, true)
+	if err != nil {
+		return err
+	}
+	to, toLabel, err := diffSide(inst, name, diffTo, false)
+	if err != nil {
+		return err
+	}
+
+	report := diffReport{Skill: name, From: fromLabel, To: toLabel, Files: []diffFile{}}
+	changes := diff.Compare(from, to)
+	paths := make([]string, len(changes))
+	for n, c := range changes {
+		paths[n] = c.Path
+	}
+	var diffs map[string]string
+	if !diffStat {
+		diffs = fileDiffs(name, from, to, paths)
+	}
+	for _, c := range changes {
+		report.Files = append(report.Files, diffFile{FileChange: c, Diff: diffs[c.Path]})
+	}
+
+	if machineOutput() {
+		return writeOutput(report)
+	}
+
+	printDiff(report, useColor())
+	return nil
+}
+
+// diffSide returns one side of a diff and its label: a registry ref when
+// given, otherwise the installed copy (from) or what update would install (to)
+func diffSide(inst *installer.Installer, name, ref string, from bool) (map[string][]byte, string, error) {
+	switch {
+	case ref != "":
+		source := ""
+		if entry, ok := inst.ManifestEntry(name); ok {
+			source = entry.Source
+		}
+		reg, err := registryAt(source, ref)
+		if err != nil {
+			return nil, "", err
+		}
+		skill, err := reg.Find(name)
+		if err != nil {
+			var suggestions []string
+			var nf *registry.NotFoundError
+			if errors.As(err, &nf) {
+				suggestions = nf.Suggestions
+			}
+			return nil, "", fmt.Errorf("skill not found at %s: %s%s", ref, name, fuzzy.DidYouMean(suggestions))
+		}
+		files, err := reg.GetFiles(skill)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to fetch %s at %s: %w", name, ref, err)
+		}
+		return files, ref, nil
+
+	case from:
+		if !inst.IsInstalled(name) {
+			installed, _ := inst.ListInstalled()
+			if suggestions := fuzzy.Suggest(name, installed); len(suggestions) > 0 {
+				return nil, "", fmt.Errorf("skill not installed: %s%s", name, fuzzy.DidYouMean(suggestions))
+			}
+			return nil, "", fmt.Errorf("skill not installed: %s (use --from and --to to compare registry refs)", name)
+		}
+		files, err := inst.ReadSkillFiles(name)
+		if err != nil {
+			return nil, "", err
+		}
+		return files, "installed", nil
+
+	default:
+		provider, err := inst.UpdateSource(name)
+		if err != nil {
+			return nil, "", err
+		}
+		files, err := inst.Fetch(provider, name)
+		if err != nil {
+			return nil, "", err
+		}
+		label := "registry"
+		if p, ok := provider.(interface{ GetRef() string }); ok {
+			label = fmt.Sprintf("registry (%s)", p.GetRef())
+		}
+		return files, label, nil
+	}
+}
+
+func printDiff(r diffReport, color bool) {
+	if len(r.Files) == 0 {
+		fmt.Printf("%s: no differences between %s and %s\n", r.Skill, r.From, r.To)
+		return
+	}
+
+	fmt.Printf("%s: %s → %s\n\n", r.Skill, r.From, r.To)
+
+	width := 0
+	for _, f := range r.Files {
+		width = max(width, len(f.Path))
+	}
+	added, removed := 0, 0
+	for _, f := range r.Files {
+		counts := colorize(fmt.Sprintf("+%d", f.Added), ansiGreen, color) + " " + colorize(fmt.Sprintf("-%d", f.Removed), ansiRed, color)
+		if f.Binary {
+			counts = "binary"
+		}
+		fmt.Printf("  %-8s %-*s  %s\n", f.Status, width, f.Path, counts)
+		added += f.Added
+		removed += f.Removed
+	}
+	fmt.Printf("\n  %d file(s) changed, %d insertion(s), %d deletion(s)\n", len(r.Files), added, removed)
+
+	for _, f := range r.Files {
+		if f.Diff == "" {
+			continue
+		}
+		fmt.Println()
+		for _, line := range diff.SplitLines(f.Diff) {
+			fmt.Print(colorDiffLine(line, color))
+		}
+	}
+}
+
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiCyan   = "\033[36m"
+)
+
+// useColor reports whether output goes to a terminal that wants color
+func useColor() bool {
+	if diffNoColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func colorize(s, code string, color bool) string {
+	if !color {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+func colorDiffLine(line string, color bool) string {
+	text := strings.TrimSuffix(line, "\n")
+	switch {
+	case strings.HasPrefix(text, "--- "), strings.HasPrefix(text, "+++ "):
+		text = colorize(text, ansiBold, color)
+	case strings.HasPrefix(text, "@@"):
+		text = colorize(text, ansiCyan, color)
+	case strings.HasPrefix(text, "+"):
+		text = colorize(text, ansiGreen, color)
+	case strings.HasPrefix(text, "-"):
+		text = colorize(text, ansiRed, color)
+	}
+	return text + "\n"
+}
@@ -0,0 +1,313 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/frontmatter"
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/markdown"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	infoFile   string
+	infoRaw    bool
+	infoRemote bool
+)
+
+var infoCmd = &cobra.Command{
+	Use:     "info <skill-name>",
+	Aliases: []string{"show"},
+	Short:   "Show a skill's details and read its SKILL.md",
+	Long: `Show a skill's metadata (stack, source, ref, version, tags, files, install
+status and local path) followed by its SKILL.md rendered for the terminal.
+
+Installed skills are read from disk, so local edits show up; other skills,
+or any skill with --remote, are read from the registry.
+
+Examples:
+  vibe-skills info code-reviewer
+  vibe-skills show dotnet/clean-architecture
+  vibe-skills info code-reviewer --file references/common_checklists.md
+  vibe-skills info code-reviewer --raw > SKILL.md
+  vibe-skills info code-reviewer --remote       # Registry copy, not the installed one`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: dryRunReadOnly, outputAnnotation: outputSupported},
+	RunE:        runInfo,
+}
+
+func init() {
+	infoCmd.Flags().StringVarP(&infoFile, "file", "f", "", "Show another file of the skill, e.g. references/performance.md")
+	infoCmd.Flags().BoolVar(&infoRaw, "raw", false, "Print the file unformatted, without metadata")
+	infoCmd.Flags().BoolVar(&infoRemote, "remote", false, "Read the registry copy even when the skill is installed")
+	infoCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Look for the skill in ~/.claude/skills")
+}
+
+// skillInfo is what info knows about a skill from disk and the registry
+type skillInfo struct {
+	name      string
+	skill     *registry.Skill // nil when read from disk only
+	entry     *installer.ManifestEntry
+	installed bool
+	local     bool // Files were read from disk
+	files     map[string][]byte
+	source    string
+	ref       string
+	commit    string // Commit an installed skill's ref resolved to
+}
+
+func runInfo(cmd *cobra.Command, args []string) error {
+	if infoRaw && machineOutput() {
+		return fmt.Errorf("--raw cannot be combined with --output %s", flagOutput)
+	}
+
+	cwd, err := scopeDir()
+	if err != nil {
+		return err
+	}
+
+	inst, err := newInstaller(nil, cwd)
+	if err != nil {
+		return err
+	}
+
+	info := &skillInfo{name: path.Base(args[0])}
+	info.installed = inst.IsInstalled(info.name)
+	info.entry, _ = inst.ManifestEntry(info.name)
+
+	if info.installed && !infoRemote {
+		info.local = true
+		info.files, err = inst.ReadSkillFiles(info.name)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", info.name, err)
+		}
+		if e := info.entry; e != nil {
+			info.source, info.ref, info.commit = e.Source, e.Ref, e.Commit
+		}
+	} else {
+		reg, err := getRegistry()
+		if err != nil {
+			return fmt.Errorf("failed to create registry: %w", err)
+		}
+		info.skill, err = reg.Find(args[0])
+		if err != nil {
+			return err
+		}
+		info.files, err = reg.GetFiles(info.skill)
+		if err != nil {
+			return err
+		}
+		info.source, info.ref = reg.Source(), reg.GetRef()
+	}
+
+	cmd.SilenceUsage = true
+
+	file := "SKILL.md"
+	if infoFile != "" {
+		file = path.Clean(filepath.ToSlash(infoFile))
+	}
+	content, ok := info.files[file]
+	if !ok {
+		return fmt.Errorf("%s has no file %s (files: %s)", info.name, file, strings.Join(sortedFiles(info.files), ", "))
+	}
+
+	if infoRaw {
+		_, err := os.Stdout.Write(content)
+		return err
+	}
+
+	if machineOutput() {
+		fm, _, _ := frontmatter.Parse(info.files["SKILL.md"])
+		report := newInfoReport(inst, info, fm, cwd)
+		report.File, report.Content = file, string(content)
+		return writeOutput(report)
+	}
+
+	width, color := terminalWidth(), isTerminal() && os.Getenv("NO_COLOR") == ""
+	if infoFile != "" {
+		if strings.EqualFold(path.Ext(file), ".md") {
+			content = frontmatter.Strip(content)
+			fmt.Print(markdown.Render(content, width, color))
+		} else {
+			fmt.Print(string(content))
+		}
+		return nil
+	}
+
+	fm, body, err := frontmatter.Parse(content)
+	if err != nil {
+		fm, body = nil, content
+	}
+	printSkillInfo(newInfoReport(inst, info, fm, cwd))
+	fmt.Println()
+	fmt.Print(markdown.Render(body, width, color))
+	return nil
+}
+
+// infoReport is the --output form of info
+type infoReport struct {
+	Name        string    `json:"name"`
+	Stack       string    `json:"stack"`
+	Description string    `json:"description"`
+	Source      string    `json:"source"`
+	Ref         string    `json:"ref"`
+	Commit      string    `json:"commit"` // Commit an installed ref resolved to, empty otherwise
+	Version     string    `json:"version"`
+	Tags        []string  `json:"tags"`
+	Status      string    `json:"status"` // Human-readable install status
+	Installed   bool      `json:"installed"`
+	Path        string    `json:"path"` // Install directory, empty when not installed
+	From        string    `json:"from"` // "installed" or "registry": where files were read
+	Files       []fileRow `json:"files"`
+	File        string    `json:"file"`    // The file in content
+	Content     string    `json:"content"` // Unformatted file content
+}
+
+type fileRow struct {
+	Path string `json:"path"`
+	Size int    `json:"size"`
+}
+
+func newInfoReport(inst *installer.Installer, info *skillInfo, fm *frontmatter.Frontmatter, cwd string) infoReport {
+	r := infoReport{
+		Name:      info.name,
+		Source:    info.source,
+		Ref:       info.ref,
+		Commit:    info.commit,
+		Tags:      []string{},
+		Status:    installStatus(inst, info),
+		Installed: info.installed,
+		From:      "registry",
+		Files:     []fileRow{},
+	}
+	if s := info.skill; s != nil {
+		r.Stack, r.Description, r.Version = s.Stack, s.Description, s.Version
+	}
+	if e := info.entry; e != nil && r.Stack == "" {
+		r.Stack = e.Stack
+	}
+	if fm != nil {
+		if fm.Description != "" {
+			r.Description = fm.Description
+		}
+		if fm.Version != "" {
+			r.Version = fm.Version
+		}
+		r.Tags = orEmpty([]string(fm.Tags))
+	}
+	if info.installed {
+		r.Path = inst.SkillDir(info.name)
+		if rel, err := filepath.Rel(cwd, r.Path); err == nil && !strings.HasPrefix(rel, "..") {
+			r.Path = rel
+		}
+	}
+	if info.local {
+		r.From = "installed"
+	}
+	for _, name := range sortedFiles(info.files) {
+		r.Files = append(r.Files, fileRow{Path: name, Size: len(info.files[name])})
+	}
+	return r
+}
+
+// printSkillInfo prints the metadata block of info
+func printSkillInfo(r infoReport) {
+	// Long values wrap under the value column
+	width := max(terminalWidth()-14, 20)
+	field := func(label, value string) {
+		if value == "" {
+			value = "-"
+		}
+		var lines []string
+		line := ""
+		for _, word := range strings.Fields(value) {
+			if line != "" && len(line)+1+len(word) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			line = strings.TrimPrefix(line+" "+word, " ")
+		}
+		lines = append(lines, line)
+		fmt.Printf("%-13s %s\n", label+":", strings.Join(lines, "\n"+strings.Repeat(" ", 14)))
+	}
+
+	field("Name", r.Name)
+	field("Stack", r.Stack)
+	field("Description", r.Description)
+	field("Source", r.Source)
+	ref := r.Ref
+	if len(r.Commit) >= 7 {
+		ref += " (" + r.Commit[:7] + ")"
+	}
+	field("Ref", ref)
+	field("Version", r.Version)
+	field("Tags", strings.Join(r.Tags, ", "))
+	field("Status", r.Status)
+	if r.Installed {
+		field("Path", r.Path)
+	}
+
+	from := "registry"
+	if r.From == "installed" {
+		from = "installed copy"
+	}
+	fmt.Printf("Files (%s):\n", from)
+	for _, f := range r.Files {
+		fmt.Printf("  %-40s %s\n", f.Path, formatSize(int64(f.Size)))
+	}
+}
+
+// installStatus describes whether and how a skill is installed
+func installStatus(inst *installer.Installer, info *skillInfo) string {
+	if !info.installed {
+		return "not installed"
+	}
+	if link, ok := inst.LinkInfo(info.name); ok {
+		return "linked from " + link.Source
+	}
+
+	status := "installed"
+	if e := info.entry; e != nil {
+		var details []string
+		if e.Version != "" {
+			details = append(details, "v"+strings.TrimPrefix(e.Version, "v"))
+		}
+		if e.Reason != "" {
+			details = append(details, e.Reason)
+		}
+		if !e.InstalledAt.IsZero() {
+			details = append(details, e.InstalledAt.Local().Format("2006-01-02"))
+		}
+		if len(details) > 0 {
+			status += " (" + strings.Join(details, ", ") + ")"
+		}
+	}
+	if s, err := inst.Status(info.name); err == nil && s.Drifted() {
+		status += ", modified locally"
+	}
+	return status
+}
+
+func sortedFiles(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// terminalWidth returns $COLUMNS capped at 100 for readability, or 80
+func terminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 20 {
+		return min(n, 100)
+	}
+	return 80
+}
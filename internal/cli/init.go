@@ -3,16 +3,43 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/cuongtl1992/vibe-skills/internal/config"
+	"github.com/cuongtl1992/vibe-skills/internal/detect"
 	"github.com/spf13/cobra"
 )
 
+var (
+	initPreset string
+	initYes    bool
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a new .vibe-skills.yaml config file",
-	Long:  `Creates a new .vibe-skills.yaml configuration file in the current directory with default skills.`,
-	RunE:  runInit,
+	Long: `Creates a new .vibe-skills.yaml configuration file in the current directory with default skills.
+
+The repository is scanned for stack signals (go.mod, package.json with
+Playwright or Cucumber, *.feature, *.csproj, pubspec.yaml, *.sql, EF
+migrations, ...) and the skills the registry's detection rules map them to
+are proposed as well. The proposal is shown for confirmation, or written
+directly with --yes.
+
+With --preset the config is seeded with the skills of a registry bundle
+instead (see 'vibe-skills list --bundles').
+
+Examples:
+  vibe-skills init
+  vibe-skills init --yes
+  vibe-skills init --preset playwright-qa`,
+	Annotations: map[string]string{dryRunAnnotation: dryRunUnsupported},
+	RunE:        runInit,
+}
+
+func init() {
+	initCmd.Flags().StringVar(&initPreset, "preset", "", "Seed the config with the skills of a registry bundle")
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "Write the detected config without asking")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -26,15 +53,82 @@ func runInit(cmd *cobra.Command, args []string) error {
 	}
 
 	cfg := config.GetDefaultConfig()
+	label := "default skills"
+
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	var matches []detect.Match
+	if initPreset != "" {
+		bundle, err := reg.FindBundle(initPreset)
+		if err != nil {
+			return fmt.Errorf("%w: run 'vibe-skills list --bundles' to see the available presets", err)
+		}
+		cfg.Skills = config.Skills(bundle.Skills...)
+		label = "the " + bundle.Name + " preset"
+	} else if rules, err := reg.DetectRules(); err != nil {
+		fmt.Printf("Skipping project detection: %v\n\n", err)
+	} else {
+		if matches, err = detect.Scan(cwd, rules); err != nil {
+			return fmt.Errorf("failed to scan project: %w", err)
+		}
+		for _, skill := range detect.Skills(matches) {
+			cfg.Skills, _ = config.AddSkill(cfg.Skills, skill)
+		}
+	}
+
+	if len(matches) > 0 {
+		cmd.SilenceUsage = true
+		printDetected(matches, cfg)
+		if !initYes {
+			if !isInteractive() {
+				return fmt.Errorf("not writing the detected config without confirmation: re-run with --yes")
+			}
+			ok, err := confirm("\nWrite this config?")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Println("Aborted.")
+				return nil
+			}
+		}
+	}
+
 	if err := config.Save(cwd, cfg); err != nil {
 		return fmt.Errorf("failed to create config file: %w", err)
 	}
 
-	fmt.Printf("Created %s with default skills:\n", config.ConfigFileName)
-	for _, skill := range cfg.Skills {
-		fmt.Printf("  - %s\n", skill)
+	if len(matches) > 0 {
+		fmt.Printf("\nCreated %s\n", config.ConfigFileName)
+	} else {
+		fmt.Printf("Created %s with %s:\n", config.ConfigFileName, label)
+		for _, skill := range cfg.Skills {
+			fmt.Printf("  - %s\n", skill)
+		}
 	}
 	fmt.Println("\nRun 'vibe-skills install' to install these skills.")
 
 	return nil
 }
+
+// printDetected shows which signals were found and the resulting proposal
+func printDetected(matches []detect.Match, cfg *config.Config) {
+	nameW, fileW := 0, 0
+	for _, m := range matches {
+		nameW = max(nameW, len(m.Rule.Name))
+		fileW = max(fileW, len(m.File))
+	}
+
+	fmt.Println("Detected:")
+	for _, m := range matches {
+		fmt.Printf("  %-*s  %-*s  → %s\n", nameW, m.Rule.Name, fileW, m.File, strings.Join(m.Rule.Skills, ", "))
+	}
+
+	fmt.Printf("\nProposed skills for %s:\n", config.ConfigFileName)
+	for _, skill := range cfg.Skills {
+		fmt.Printf("  - %s\n", skill)
+	}
+}
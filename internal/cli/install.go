@@ -2,7 +2,6 @@ package cli
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/cuongtl1992/vibe-skills/internal/config"
@@ -11,9 +10,12 @@ import (
 )
 
 var (
-	installStack string
-	installAll   bool
-	installForce bool
+	installStack       string
+	installBundle      string
+	installAll         bool
+	installForce       bool
+	installNoSave      bool
+	installInteractive bool
 )
 
 var installCmd = &cobra.Command{
@@ -21,27 +23,53 @@ var installCmd = &cobra.Command{
 	Short: "Install skills to the current project",
 	Long: `Install one or more skills to the current project.
 
-Skills are installed to .claude/skills/ directory.
+Skills are installed to .claude/skills/ directory, or to ~/.claude/skills/
+with --global (reading the 'skills' list of ~/.vibe-skills/config.yaml when
+no skills are given). With --target (or 'targets' in the config) the skills
+are also converted for other agents: Cursor rules, Copilot instructions and
+managed sections in AGENTS.md for Codex.
+
+Named skills, bundles and stacks are added to .vibe-skills.yaml (or the
+global config), which is created if needed; use --no-save to skip.
 
 Examples:
   vibe-skills install                     # Install from .vibe-skills.yaml
   vibe-skills install commit-convention   # Install a specific skill
   vibe-skills install ef-core sql-opt     # Install multiple skills
   vibe-skills install --stack dotnet      # Install all skills from a stack
-  vibe-skills install --all               # Install all available skills`,
-	RunE: runInstall,
+  vibe-skills install -b playwright-qa    # Install a curated bundle
+  vibe-skills install --all               # Install all available skills
+  vibe-skills install -i                  # Pick skills interactively
+  vibe-skills install --no-save tdd       # Install without adding it to the config
+  vibe-skills install -g commit-convention # Install for every project
+  vibe-skills install --target claude,cursor,copilot,codex`,
+	Annotations: map[string]string{dryRunAnnotation: dryRunSupported, outputAnnotation: outputSupported},
+	RunE:        runInstall,
 }
 
 func init() {
 	installCmd.Flags().StringVarP(&installStack, "stack", "s", "", "Install all skills from specified stack(s), comma-separated")
+	installCmd.Flags().StringVarP(&installBundle, "bundle", "b", "", "Install the skills of registry bundle(s), comma-separated (see 'list --bundles')")
 	installCmd.Flags().BoolVarP(&installAll, "all", "a", false, "Install all available skills")
 	installCmd.Flags().BoolVarP(&installForce, "force", "f", false, "Overwrite existing skills")
+	installCmd.Flags().BoolVarP(&installInteractive, "interactive", "i", false, "Pick skills in an interactive browser (same as 'browse')")
+	installCmd.Flags().BoolVar(&installNoSave, "no-save", false, "Don't add the skills or stacks to the config")
+	installCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Install to ~/.claude/skills for all projects")
+	addTargetFlag(installCmd)
+	installCmd.Flags().BoolVar(&flagStore, "store", false, "Store skills once in ~/.vibe-skills/store and link them into the project")
 }
 
 func runInstall(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	if installInteractive {
+		if len(args) > 0 || installStack != "" || installBundle != "" || installAll {
+			return fmt.Errorf("--interactive cannot be combined with skill names, --stack, --bundle or --all")
+		}
+		return runBrowse(cmd, args)
+	}
+
+	cwd, err := scopeDir()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
 
 	reg, err := getRegistry()
@@ -51,9 +79,12 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Using registry: %s\n\n", reg.GetRef())
 
-	inst := installer.New(reg, cwd)
+	inst, err := newInstaller(reg, cwd)
+	if err != nil {
+		return err
+	}
 
-	var installed []string
+	var installed, savedSkills, savedStacks []string
 	var errors []error
 
 	switch {
@@ -67,18 +98,91 @@ func runInstall(cmd *cobra.Command, args []string) error {
 			i, e := inst.InstallStack(stack)
 			installed = append(installed, i...)
 			errors = append(errors, e...)
+			if len(i) > 0 {
+				savedStacks = append(savedStacks, stack)
+			}
+		}
+
+	case installBundle != "":
+		for _, name := range strings.Split(installBundle, ",") {
+			bundle, err := reg.FindBundle(strings.TrimSpace(name))
+			if err != nil {
+				errors = append(errors, err)
+				continue
+			}
+			inst.SetReason(installer.ReasonBundle + ":" + bundle.Name)
+			i, e := inst.InstallMultiple(bundle.Skills)
+			installed = append(installed, i...)
+			errors = append(errors, e...)
+			savedSkills = append(savedSkills, i...)
 		}
 
 	case len(args) > 0:
 		installed, errors = inst.InstallMultiple(args)
+		for _, name := range installed {
+			// Record stack/name, like the default config does
+			if skill, err := reg.Find(name); err == nil {
+				name = skill.Stack + "/" + skill.Name
+			}
+			savedSkills = append(savedSkills, name)
+		}
+
+	case flagGlobal:
+		// Install from global config
+		globalCfg, err := config.LoadGlobal()
+		if err != nil {
+			return fmt.Errorf("failed to load global config: %w", err)
+		}
+		if len(globalCfg.Skills)+len(globalCfg.Stacks) == 0 {
+			return fmt.Errorf("no skills specified and no 'skills' list in ~/%s/%s", config.GlobalConfigDir, config.GlobalConfigFileName)
+		}
+		declared, err := declaredSkills(cwd, reg)
+		if err != nil {
+			return err
+		}
+		inst.SetReason(installer.ReasonConfig)
+		installed, errors = inst.InstallSpecs(declared)
 
 	default:
 		// Install from config file
-		cfg, err := config.Load(cwd)
-		if err != nil {
+		if !config.Exists(cwd) {
 			return fmt.Errorf("no skills specified and no config file found: run 'vibe-skills init' to create a config file, or specify skills to install")
 		}
-		installed, errors = inst.InstallMultiple(cfg.Skills)
+		declared, err := declaredSkills(cwd, reg)
+		if err != nil {
+			return err
+		}
+		inst.SetReason(installer.ReasonConfig)
+		installed, errors = inst.InstallSpecs(declared)
+	}
+
+	saved := false
+	if !installNoSave && len(savedSkills)+len(savedStacks) > 0 {
+		saved, err = saveInstalled(cwd, savedSkills, savedStacks)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", configName(), err)
+		}
+	}
+
+	if flagDryRun {
+		if saved {
+			inst.PlanFile(configFile())
+		}
+		return reportDryRun(inst, errors)
+	}
+
+	if machineOutput() {
+		if err := writeOutput(installReport{
+			Installed: orEmpty(installed),
+			Saved:     saved,
+			Errors:    outputErrors(errors),
+		}); err != nil {
+			return err
+		}
+		if len(errors) > 0 {
+			return fmt.Errorf("some skills failed to install")
+		}
+		return nil
 	}
 
 	// Print results
@@ -88,6 +192,9 @@ func runInstall(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  ✓ %s\n", name)
 		}
 	}
+	if saved {
+		fmt.Printf("\nSaved to %s\n", configName())
+	}
 
 	if len(errors) > 0 {
 		fmt.Printf("\nFailed to install %d skill(s):\n", len(errors))
@@ -103,3 +210,29 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// installReport is the --output form of install
+type installReport struct {
+	Installed []string      `json:"installed"`
+	Saved     bool          `json:"saved"` // The config file gained skills or stacks
+	Errors    []outputError `json:"errors"`
+}
+
+// saveInstalled adds installed skills and stacks to the config for the
+// current scope and reports whether any were new
+func saveInstalled(dir string, skills, stacks []string) (changed bool, err error) {
+	err = editConfig(dir, func(cfgSkills *[]config.SkillEntry, cfgStacks *[]string) bool {
+		for _, skill := range skills {
+			var added bool
+			*cfgSkills, added = config.AddSkill(*cfgSkills, skill)
+			changed = changed || added
+		}
+		for _, stack := range stacks {
+			var added bool
+			*cfgStacks, added = config.AddStack(*cfgStacks, stack)
+			changed = changed || added
+		}
+		return changed
+	})
+	return changed, err
+}
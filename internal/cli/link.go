@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link <skill-dir>",
+	Short: "Link a local skill directory into the project",
+	Long: `Link a local skill directory into .claude/skills/<name> for development.
+
+The skill is symlinked, so edits show up immediately. Where symlinks are
+unavailable the directory is mirrored instead; run 'vibe-skills update <name>'
+to refresh a mirror. An installed registry copy is set aside and restored by
+'vibe-skills unlink'.
+
+Examples:
+  vibe-skills link ../our-skills/testing/my-skill
+  vibe-skills unlink my-skill`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: dryRunUnsupported},
+	RunE:        runLink,
+}
+
+var unlinkCmd = &cobra.Command{
+	Use:   "unlink <skill-name>",
+	Short: "Remove a linked skill and restore the installed copy",
+	Long: `Remove a skill created with 'vibe-skills link'. If a registry copy was
+installed before linking, it is restored. The linked source directory is
+never modified.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: dryRunUnsupported},
+	RunE:        runUnlink,
+}
+
+func runLink(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	inst, err := newInstaller(nil, cwd)
+	if err != nil {
+		return err
+	}
+
+	info, err := inst.Link(args[0])
+	if err != nil {
+		return err
+	}
+
+	mode := "symlinked"
+	if info.Mirrored {
+		mode = "mirrored (symlinks unavailable)"
+	}
+	fmt.Printf("  ✓ %s -> %s [%s]\n", info.Name, info.Source, mode)
+	return nil
+}
+
+func runUnlink(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	inst, err := newInstaller(nil, cwd)
+	if err != nil {
+		return err
+	}
+
+	restored, err := inst.Unlink(args[0])
+	if err != nil {
+		return err
+	}
+
+	if restored {
+		fmt.Printf("  ✓ %s unlinked, installed copy restored\n", args[0])
+	} else {
+		fmt.Printf("  ✓ %s unlinked\n", args[0])
+	}
+	return nil
+}
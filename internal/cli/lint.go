@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cuongtl1992/vibe-skills/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintStrict bool
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [path]",
+	Short: "Validate a skill directory or a skills tree",
+	Long: `Validate skills before publishing them to a registry.
+
+The path can be a single skill directory (containing SKILL.md) or a tree
+such as skills/. Defaults to ./skills if it exists, otherwise the current
+directory.
+
+Checks:
+  - SKILL.md has frontmatter with 'name' and 'description'
+  - name matches the skill folder
+  - description length and "when to use" trigger wording
+  - relative markdown links resolve to files in the skill
+  - unreferenced, oversized, non-UTF-8 and registry-excluded files
+
+Examples:
+  vibe-skills lint
+  vibe-skills lint skills/common/code-reviewer
+  vibe-skills lint skills --json
+  vibe-skills lint --strict            # Fail on warnings too`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: dryRunReadOnly, outputAnnotation: outputSupported},
+	RunE:        runLint,
+}
+
+func init() {
+	lintCmd.Flags().BoolVar(&flagJSON, "json", false, "Output results as JSON (same as --output json)")
+	lintCmd.Flags().BoolVar(&lintStrict, "strict", false, "Exit non-zero on warnings as well as errors")
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	} else if info, err := os.Stat("skills"); err == nil && info.IsDir() {
+		root = "skills"
+	}
+
+	cmd.SilenceUsage = true
+
+	result, err := lint.New(nil).Lint(root)
+	if err != nil {
+		return fmt.Errorf("failed to lint %s: %w", root, err)
+	}
+
+	if machineOutput() {
+		if err := writeOutput(result); err != nil {
+			return err
+		}
+	} else {
+		for _, issue := range result.Issues {
+			fmt.Println(issue)
+		}
+		if len(result.Issues) > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Checked %d skill(s): %d error(s), %d warning(s)\n",
+			len(result.Skills), result.Errors(), result.Warnings())
+	}
+
+	if result.Errors() > 0 || (lintStrict && result.Warnings() > 0) {
+		return fmt.Errorf("lint failed")
+	}
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/cuongtl1992/vibe-skills/internal/fuzzy"
 	"github.com/cuongtl1992/vibe-skills/internal/installer"
 	"github.com/cuongtl1992/vibe-skills/internal/registry"
 	"github.com/spf13/cobra"
@@ -14,6 +16,7 @@ import (
 var (
 	listStack     string
 	listInstalled bool
+	listBundles   bool
 )
 
 var listCmd = &cobra.Command{
@@ -25,20 +28,26 @@ var listCmd = &cobra.Command{
 Examples:
   vibe-skills list                    # List all available skills
   vibe-skills list --stack dotnet     # List skills in dotnet stack
-  vibe-skills list --installed        # List installed skills only
-  vibe-skills list --branch develop   # List skills from develop branch`,
-	RunE: runList,
+  vibe-skills list --installed        # List installed skills (project and global)
+  vibe-skills list --installed -g     # List globally installed skills only
+  vibe-skills list --bundles          # List curated skill bundles
+  vibe-skills list --branch develop   # List skills from develop branch
+  vibe-skills list -o json            # Machine-readable output (see docs/output.md)`,
+	Annotations: map[string]string{dryRunAnnotation: dryRunReadOnly, outputAnnotation: outputSupported},
+	RunE:        runList,
 }
 
 func init() {
 	listCmd.Flags().StringVarP(&listStack, "stack", "s", "", "Filter by stack")
 	listCmd.Flags().BoolVarP(&listInstalled, "installed", "i", false, "List installed skills only")
+	listCmd.Flags().BoolVarP(&listBundles, "bundles", "b", false, "List skill bundles published by the registry")
+	listCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Use ~/.claude/skills instead of the project")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := scopeDir()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
 
 	reg, err := getRegistry()
@@ -48,22 +57,17 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	inst := installer.New(reg, cwd)
 
-	if listInstalled {
-		installed, err := inst.ListInstalled()
-		if err != nil {
-			return fmt.Errorf("failed to list installed skills: %w", err)
-		}
-
-		if len(installed) == 0 {
-			fmt.Println("No skills installed in this project.")
-			return nil
-		}
+	homeDir, _ := os.UserHomeDir()
+	var global *installer.Installer
+	if homeDir != "" && homeDir != cwd {
+		global = installer.New(reg, homeDir)
+	}
 
-		fmt.Printf("Installed skills (%d):\n", len(installed))
-		for _, name := range installed {
-			fmt.Printf("  %s\n", name)
-		}
-		return nil
+	if listInstalled {
+		return listInstalledSkills(inst, global)
+	}
+	if listBundles {
+		return listRegistryBundles(reg)
 	}
 
 	var skills []registry.Skill
@@ -72,9 +76,10 @@ func runList(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return fmt.Errorf("failed to list skills: %w", err)
 		}
-		if len(skills) == 0 {
-			fmt.Printf("No skills found in stack: %s\n", listStack)
+		if len(skills) == 0 && !machineOutput() {
 			stacks, _ := reg.GetStacks()
+			sort.Strings(stacks)
+			fmt.Printf("No skills found in stack: %s%s\n", listStack, fuzzy.DidYouMean(fuzzy.Suggest(listStack, stacks)))
 			if len(stacks) > 0 {
 				fmt.Println("\nAvailable stacks:")
 				for _, stack := range stacks {
@@ -90,6 +95,16 @@ func runList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if machineOutput() {
+		sort.SliceStable(skills, func(i, j int) bool {
+			if skills[i].Stack != skills[j].Stack {
+				return skills[i].Stack < skills[j].Stack
+			}
+			return skills[i].Name < skills[j].Name
+		})
+		return writeOutput(skillRows(skills, inst, global))
+	}
+
 	if len(skills) == 0 {
 		fmt.Println("No skills available.")
 		return nil
@@ -123,6 +138,8 @@ func runList(cmd *cobra.Command, args []string) error {
 			installed := ""
 			if inst.IsInstalled(skill.Name) {
 				installed = " [installed]"
+			} else if global != nil && global.IsInstalled(skill.Name) {
+				installed = " [installed globally]"
 			}
 			if skill.Description != "" {
 				fmt.Printf("  %-25s %s%s\n", skill.Name, skill.Description, installed)
@@ -134,3 +151,225 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// skillRow is the --output form of a registry skill, for list and search
+type skillRow struct {
+	Stack       string `json:"stack"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+	Installed   string `json:"installed"` // "project", "global" or ""
+}
+
+func skillRows(skills []registry.Skill, inst, global *installer.Installer) []skillRow {
+	rows := []skillRow{}
+	for _, s := range skills {
+		rows = append(rows, skillRow{
+			Stack:       s.Stack,
+			Name:        s.Name,
+			Description: s.Description,
+			Version:     s.Version,
+			Installed:   installedIn(inst, global, s.Name),
+		})
+	}
+	return rows
+}
+
+// installedIn names the scope a skill is installed in: the current scope
+// (inst) first, then the global one
+func installedIn(inst, global *installer.Installer, name string) string {
+	switch {
+	case inst != nil && inst.IsInstalled(name):
+		if flagGlobal {
+			return "global"
+		}
+		return "project"
+	case global != nil && global.IsInstalled(name):
+		return "global"
+	}
+	return ""
+}
+
+// listRegistryBundles prints each bundle with its description and skills
+func listRegistryBundles(reg *registry.GitHubRegistry) error {
+	bundles, err := reg.ListBundles()
+	if err != nil {
+		return fmt.Errorf("failed to list bundles: %w", err)
+	}
+	if machineOutput() {
+		if bundles == nil {
+			bundles = []registry.Bundle{}
+		}
+		return writeOutput(bundles)
+	}
+	if len(bundles) == 0 {
+		fmt.Println("No bundles available.")
+		return nil
+	}
+
+	fmt.Printf("Registry: %s\n", reg.GetRef())
+	for _, b := range bundles {
+		fmt.Printf("\n%-25s %s\n", b.Name, b.Description)
+		for _, skill := range b.Skills {
+			fmt.Printf("  - %s\n", skill)
+		}
+	}
+	fmt.Println("\nInstall one with 'vibe-skills install --bundle <name>', or start a project with 'vibe-skills init --preset <name>'.")
+	return nil
+}
+
+// listInstalledSkills prints installed skills for the project (or --global)
+// scope, followed by global skills and which of them a project skill shadows
+func listInstalledSkills(inst, global *installer.Installer) error {
+	installed, err := inst.ListInstalled()
+	if err != nil {
+		return fmt.Errorf("failed to list installed skills: %w", err)
+	}
+
+	var globalInstalled []string
+	if global != nil {
+		globalInstalled, err = global.ListInstalled()
+		if err != nil {
+			return fmt.Errorf("failed to list global skills: %w", err)
+		}
+	}
+
+	if machineOutput() {
+		scope := "project"
+		if flagGlobal {
+			scope = "global"
+		}
+		rows := installedRows(inst, installed, scope, globalInstalled)
+		if global != nil {
+			rows = append(rows, installedRows(global, globalInstalled, "global", installed)...)
+		}
+		return writeOutput(rows)
+	}
+
+	if len(installed) == 0 && len(globalInstalled) == 0 {
+		if flagGlobal {
+			fmt.Println("No skills installed globally.")
+		} else {
+			fmt.Println("No skills installed in this project.")
+		}
+		return nil
+	}
+
+	inScope := make(map[string]bool)
+	for _, name := range installed {
+		inScope[name] = true
+	}
+	inGlobal := make(map[string]bool)
+	for _, name := range globalInstalled {
+		inGlobal[name] = true
+	}
+
+	title := "Installed skills"
+	if flagGlobal {
+		title = "Global skills (~/" + installer.TargetDir + ")"
+	} else if global != nil {
+		title = "Project skills (" + installer.TargetDir + ")"
+	}
+	printInstalled(title, inst, installed, func(name string) string {
+		if inGlobal[name] {
+			return " [shadows global]"
+		}
+		return ""
+	})
+
+	if global != nil && len(globalInstalled) > 0 {
+		fmt.Println()
+		printInstalled("Global skills (~/"+installer.TargetDir+")", global, globalInstalled, func(name string) string {
+			if inScope[name] {
+				return " [shadowed by project]"
+			}
+			return ""
+		})
+	}
+	return nil
+}
+
+func printInstalled(title string, inst *installer.Installer, names []string, mark func(string) string) {
+	manifest, err := inst.LoadManifest()
+	if err != nil {
+		manifest = &installer.Manifest{}
+	}
+
+	fmt.Printf("%s (%d):\n", title, len(names))
+	for _, name := range names {
+		if info, ok := inst.LinkInfo(name); ok {
+			fmt.Printf("  %s -> %s [linked]%s\n", name, info.Source, mark(name))
+		} else if entry, ok := manifest.Skills[name]; ok {
+			fmt.Printf("  %-25s %s%s\n", name, provenance(entry), mark(name))
+		} else {
+			fmt.Printf("  %-25s (unknown source)%s\n", name, mark(name))
+		}
+	}
+}
+
+// installedRow is the --output form of an installed skill
+type installedRow struct {
+	Name        string `json:"name"`
+	Scope       string `json:"scope"` // "project" or "global"
+	Source      string `json:"source"`
+	Ref         string `json:"ref"`
+	Commit      string `json:"commit"`
+	Version     string `json:"version"`
+	Reason      string `json:"reason"`
+	InstalledAt string `json:"installed_at"` // RFC 3339, empty when unknown
+	LinkedFrom  string `json:"linked_from"`
+	Shadowed    bool   `json:"shadowed"` // A project skill of the same name takes precedence
+}
+
+// installedRows describes the skills installed in one scope. shadowing lists
+// the other scope's skills; they shadow global skills of the same name.
+func installedRows(inst *installer.Installer, names []string, scope string, shadowing []string) []installedRow {
+	manifest, err := inst.LoadManifest()
+	if err != nil {
+		manifest = &installer.Manifest{}
+	}
+	shadowed := make(map[string]bool)
+	if scope == "global" && !flagGlobal {
+		for _, name := range shadowing {
+			shadowed[name] = true
+		}
+	}
+
+	rows := []installedRow{}
+	for _, name := range names {
+		row := installedRow{Name: name, Scope: scope, Shadowed: shadowed[name]}
+		if info, ok := inst.LinkInfo(name); ok {
+			row.LinkedFrom = info.Source
+		} else if e, ok := manifest.Skills[name]; ok {
+			row.Source, row.Ref, row.Commit, row.Version, row.Reason = e.Source, e.Ref, e.Commit, e.Version, e.Reason
+			if !e.InstalledAt.IsZero() {
+				row.InstalledAt = e.InstalledAt.Format(time.RFC3339)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// provenance summarises a manifest entry, e.g.
+// "github.com/o/r@main (1a2b3c4) v1.2, explicit, 2024-05-01"
+func provenance(entry *installer.ManifestEntry) string {
+	var b strings.Builder
+	b.WriteString(entry.Source)
+	if entry.Ref != "" {
+		b.WriteString("@" + entry.Ref)
+	}
+	if len(entry.Commit) >= 7 {
+		b.WriteString(" (" + entry.Commit[:7] + ")")
+	}
+	if entry.Version != "" {
+		b.WriteString(" v" + strings.TrimPrefix(entry.Version, "v"))
+	}
+	if entry.Reason != "" {
+		b.WriteString(", " + entry.Reason)
+	}
+	if !entry.InstalledAt.IsZero() {
+		b.WriteString(", " + entry.InstalledAt.Local().Format("2006-01-02"))
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newTemplate    string
+	newDescription string
+	newReferences  bool
+	newScripts     bool
+	newDir         string
+)
+
+var newCmd = &cobra.Command{
+	Use:   "new <stack>/<name>",
+	Short: "Create a new skill from a template",
+	Long: `Scaffold a new skill directory with a frontmatter-correct SKILL.md.
+
+The skill is created under ./skills/<stack>/<name>/. If skills/registry.json
+exists it is regenerated to include the new skill.
+
+Templates:
+  workflow    When to Use, step-by-step Workflow and Examples (default)
+  reference   Quick reference tables, guidelines and good/bad examples
+  checklist   Review checklist grouped by area
+
+Examples:
+  vibe-skills new testing/api-contracts
+  vibe-skills new database/postgres-expert --template reference --references
+  vibe-skills new common/pr-checklist --template checklist -d "PR review checklist. Use when ..."`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: dryRunUnsupported},
+	RunE:        runNew,
+}
+
+func init() {
+	newCmd.Flags().StringVarP(&newTemplate, "template", "t", scaffold.TemplateWorkflow, "Template to use: "+strings.Join(scaffold.Templates, "|"))
+	newCmd.Flags().StringVarP(&newDescription, "description", "d", "", "Skill description for the frontmatter")
+	newCmd.Flags().BoolVar(&newReferences, "references", false, "Create a references/ folder")
+	newCmd.Flags().BoolVar(&newScripts, "scripts", false, "Create a scripts/ folder")
+	newCmd.Flags().StringVar(&newDir, "dir", "skills", "Skills directory to create the skill in")
+}
+
+func runNew(cmd *cobra.Command, args []string) error {
+	stack, name, err := scaffold.ParseName(args[0])
+	if err != nil {
+		return err
+	}
+
+	result, err := scaffold.Create(newDir, &scaffold.Options{
+		Stack:       stack,
+		Name:        name,
+		Description: newDescription,
+		Template:    newTemplate,
+		References:  newReferences,
+		Scripts:     newScripts,
+	})
+	if result != nil {
+		fmt.Printf("Created %s/%s:\n", stack, name)
+		for _, f := range result.Files {
+			fmt.Printf("  ✓ %s\n", f)
+		}
+		if result.RegistryUpdated {
+			fmt.Printf("  ✓ %s/registry.json updated\n", newDir)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\nEdit %s/SKILL.md, then run 'vibe-skills lint %s'.\n", result.Dir, result.Dir)
+	return nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/spf13/cobra"
+)
+
+var outdatedCmd = &cobra.Command{
+	Use:   "outdated [skill-names...]",
+	Short: "List installed skills with newer versions available",
+	Long: `Compare each installed skill with what its registry offers now and list
+the ones an update would change. Local edits do not count as outdated.
+
+Exits with status 1 when any skill is outdated.
+
+Examples:
+  vibe-skills outdated
+  vibe-skills outdated code-reviewer
+  vibe-skills outdated --json
+  vibe-skills outdated --global`,
+	Annotations: map[string]string{dryRunAnnotation: dryRunReadOnly, outputAnnotation: outputSupported},
+	RunE:        runOutdated,
+}
+
+func init() {
+	outdatedCmd.Flags().BoolVar(&flagJSON, "json", false, "Output results as JSON (same as --output json)")
+	outdatedCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Check skills in ~/.claude/skills")
+}
+
+func runOutdated(cmd *cobra.Command, args []string) error {
+	reg, err := getRegistry()
+	if err != nil {
+		return err
+	}
+
+	cwd, err := scopeDir()
+	if err != nil {
+		return err
+	}
+
+	inst, err := newInstaller(reg, cwd)
+	if err != nil {
+		return err
+	}
+
+	names := args
+	if len(names) == 0 {
+		names, err = inst.ListInstalled()
+		if err != nil {
+			return fmt.Errorf("failed to list installed skills: %w", err)
+		}
+	}
+
+	cmd.SilenceUsage = true
+
+	updates := []*installer.SkillUpdate{}
+	var errors []error
+	outdated := 0
+	for _, name := range names {
+		// Linked skills follow their local source, not the registry
+		if inst.IsLinked(name) {
+			continue
+		}
+		u, err := inst.CheckUpdate(name)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if u.Outdated {
+			outdated++
+		}
+		updates = append(updates, u)
+	}
+
+	if machineOutput() {
+		if err := writeOutput(updates); err != nil {
+			return err
+		}
+	} else {
+		printOutdated(updates)
+	}
+	for _, err := range errors {
+		fmt.Printf("  ✗ %s\n", err)
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("failed to check %d skill(s)", len(errors))
+	}
+	if outdated > 0 {
+		return fmt.Errorf("%d skill(s) outdated", outdated)
+	}
+	return nil
+}
+
+func printOutdated(updates []*installer.SkillUpdate) {
+	var rows []*installer.SkillUpdate
+	for _, u := range updates {
+		if u.Outdated {
+			rows = append(rows, u)
+		}
+	}
+	if len(rows) == 0 {
+		fmt.Println("All installed skills are up to date.")
+		return
+	}
+
+	nameW, curW, latW := len("SKILL"), len("CURRENT"), len("LATEST")
+	for _, u := range rows {
+		nameW = max(nameW, len(u.Name))
+		curW = max(curW, len(u.Current))
+		latW = max(latW, len(u.Latest))
+	}
+
+	fmt.Printf("  %-*s  %-*s  %-*s  %s\n", nameW, "SKILL", curW, "CURRENT", latW, "LATEST", "CHANGED")
+	for _, u := range rows {
+		fmt.Printf("  %-*s  %-*s  %-*s  %s\n", nameW, u.Name, curW, u.Current, latW, u.Latest, strings.Join(u.Changed, ", "))
+	}
+	fmt.Printf("\nRun 'vibe-skills update' to update, or 'vibe-skills diff <skill>' to review the changes.\n")
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats for --output
+const (
+	outputTable = "table" // Formatted for people (default)
+	outputPlain = "plain" // One record per line, tab-separated, no decoration
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+var outputFormats = []string{outputTable, outputPlain, outputJSON, outputYAML}
+
+// outputAnnotation marks commands that can write a report with --output
+// json|yaml|plain; see docs/output.md for each command's schema
+const (
+	outputAnnotation = "output"
+	outputSupported  = "supported"
+)
+
+var (
+	flagOutput string
+	flagJSON   bool // Per-command --json, shorthand for --output json
+
+	// stdout is where reports are written. In machine-readable modes
+	// os.Stdout is pointed at stderr so progress text stays out of the way.
+	stdout io.Writer = os.Stdout
+
+	// reported is set once a command has written its report, so a failure
+	// afterwards is not reported a second time
+	reported bool
+)
+
+// checkOutput validates --output, folds in --json and, for machine-readable
+// formats, moves everything else the command prints to stderr
+func checkOutput(cmd *cobra.Command) error {
+	if flagJSON {
+		if flagOutput != "" && flagOutput != outputJSON {
+			return fmt.Errorf("--json conflicts with --output %s", flagOutput)
+		}
+		flagOutput = outputJSON
+	}
+
+	switch flagOutput {
+	case "", outputTable:
+		return nil
+	case outputPlain, outputJSON, outputYAML:
+	default:
+		format := flagOutput
+		flagOutput = ""
+		return fmt.Errorf("unknown output format %q (use %s)", format, strings.Join(outputFormats, ", "))
+	}
+
+	if cmd.Annotations[outputAnnotation] != outputSupported {
+		format := flagOutput
+		flagOutput = ""
+		return fmt.Errorf("--output %s is not supported by '%s'", format, cmd.CommandPath())
+	}
+
+	stdout = os.Stdout
+	os.Stdout = os.Stderr
+	return nil
+}
+
+// machineOutput reports whether the command should write a report instead
+// of its formatted output
+func machineOutput() bool {
+	switch flagOutput {
+	case outputPlain, outputJSON, outputYAML:
+		return true
+	}
+	return false
+}
+
+// outputError is how errors appear in reports
+type outputError struct {
+	Message string `json:"message"`
+}
+
+func outputErrors(errs []error) []outputError {
+	out := []outputError{}
+	for _, err := range errs {
+		out = append(out, outputError{Message: err.Error()})
+	}
+	return out
+}
+
+// orEmpty keeps empty lists as [] rather than null in reports
+func orEmpty[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// writeOutput writes a report in the selected format. Field names and order
+// come from the json tags of v, for every format.
+func writeOutput(v any) error {
+	reported = true
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if flagOutput == outputJSON {
+		var indented strings.Builder
+		enc := json.NewEncoder(&indented)
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, indented.String())
+		return err
+	}
+
+	// JSON is valid YAML, so decoding it keeps the field order
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	node := doc.Content[0]
+
+	if flagOutput == outputYAML {
+		resetStyle(node)
+		enc := yaml.NewEncoder(stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(node); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+
+	for _, line := range plainLines(node) {
+		if _, err := fmt.Fprintln(stdout, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeErrorOutput reports a failed command as {"error": {"message": ...}}
+// unless the command already wrote its report
+func writeErrorOutput(err error) {
+	if !machineOutput() || reported {
+		return
+	}
+	_ = writeOutput(struct {
+		Error outputError `json:"error"`
+	}{outputError{Message: err.Error()}})
+}
+
+// resetStyle drops the JSON quoting and flow style from a decoded node
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
+
+// plainLines flattens a report: a list gives one line per element, an object
+// one "key<TAB>value" line per field, with lists of objects repeated per element
+func plainLines(n *yaml.Node) []string {
+	switch n.Kind {
+	case yaml.SequenceNode:
+		var lines []string
+		for _, c := range n.Content {
+			lines = append(lines, plainRow(c))
+		}
+		return lines
+	case yaml.MappingNode:
+		var lines []string
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i].Value, n.Content[i+1]
+			if value.Kind == yaml.SequenceNode && len(value.Content) > 0 && value.Content[0].Kind == yaml.MappingNode {
+				for _, c := range value.Content {
+					lines = append(lines, key+"\t"+plainRow(c))
+				}
+				continue
+			}
+			lines = append(lines, key+"\t"+plainRow(value))
+		}
+		return lines
+	}
+	return []string{plainValue(n)}
+}
+
+// plainRow joins the fields of an object with tabs
+func plainRow(n *yaml.Node) string {
+	if n.Kind != yaml.MappingNode {
+		return plainValue(n)
+	}
+	var fields []string
+	for i := 1; i < len(n.Content); i += 2 {
+		fields = append(fields, plainValue(n.Content[i]))
+	}
+	return strings.Join(fields, "\t")
+}
+
+// plainValue prints a scalar, or a list of scalars joined by commas. Nested
+// objects are left out; tabs and newlines inside values become spaces.
+func plainValue(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.Tag == "!!null" {
+			return ""
+		}
+		return strings.NewReplacer("\t", " ", "\r", "", "\n", " ").Replace(n.Value)
+	case yaml.SequenceNode:
+		var items []string
+		for _, c := range n.Content {
+			if c.Kind == yaml.ScalarNode {
+				items = append(items, plainValue(c))
+			}
+		}
+		return strings.Join(items, ",")
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/updater"
+	"github.com/spf13/cobra"
+)
+
+// dryRunAnnotation marks how a command treats --dry-run. Commands must opt
+// in: without the annotation the flag is rejected, so a new command that
+// writes can never ignore it.
+const (
+	dryRunAnnotation  = "dry-run"
+	dryRunSupported   = "supported"   // Plans its changes instead of writing
+	dryRunReadOnly    = "read-only"   // Never writes, so the flag changes nothing
+	dryRunUnsupported = "unsupported" // Writes and cannot plan
+)
+
+// checkDryRun rejects --dry-run on commands that neither plan their changes
+// nor only read
+func checkDryRun(cmd *cobra.Command, args []string) error {
+	if !flagDryRun {
+		return nil
+	}
+	switch cmd.Annotations[dryRunAnnotation] {
+	case dryRunSupported, dryRunReadOnly:
+		return nil
+	}
+	return fmt.Errorf("--dry-run is not supported by '%s'", cmd.CommandPath())
+}
+
+// reportDryRun prints the plan collected by a dry-run installer along with
+// any errors hit while resolving it
+func reportDryRun(inst *installer.Installer, errors []error) error {
+	if machineOutput() {
+		if err := writeOutput(planReport{
+			DryRun: true,
+			Skills: orEmpty(inst.Plan().Skills),
+			Files:  inst.Plan().Files,
+			Errors: outputErrors(errors),
+		}); err != nil {
+			return err
+		}
+		if len(errors) > 0 {
+			return fmt.Errorf("some skills could not be planned")
+		}
+		return nil
+	}
+
+	printPlan(inst.Plan())
+
+	if len(errors) > 0 {
+		fmt.Printf("\nFailed to plan %d skill(s):\n", len(errors))
+		for _, err := range errors {
+			fmt.Printf("  ✗ %s\n", err)
+		}
+		return fmt.Errorf("some skills could not be planned")
+	}
+	return nil
+}
+
+// planReport is the --output form of a dry run
+type planReport struct {
+	DryRun bool                   `json:"dry_run"`
+	Skills []*installer.SkillPlan `json:"skills"`
+	Files  []installer.FileOp     `json:"files"`
+	Errors []outputError          `json:"errors"`
+}
+
+func printPlan(plan *installer.Plan) {
+	fmt.Println("Dry run: nothing was written.")
+	if len(plan.Skills) == 0 {
+		fmt.Println("\nNo changes.")
+		return
+	}
+
+	changes := 0
+	for _, sp := range plan.Skills {
+		fmt.Printf("\n%s %s\n", sp.Action, sp.Skill)
+		printFileOps(sp.Files)
+		for _, c := range sp.Conflicts {
+			fmt.Printf("  conflict  %s (%s)\n", c.File, c.Reason)
+		}
+		changes += sp.Changes()
+	}
+	if len(plan.Files) > 0 {
+		fmt.Println("\nshared")
+		printFileOps(plan.Files)
+		changes += len(plan.Files)
+	}
+	fmt.Printf("\n%d file change(s) across %d skill(s)\n", changes, len(plan.Skills))
+}
+
+func printFileOps(files []installer.FileOp) {
+	for _, f := range files {
+		if f.Size > 0 && f.Op != installer.OpUnchanged {
+			fmt.Printf("  %-9s %s (%s)\n", f.Op, scopePath(f.Path), formatSize(f.Size))
+		} else {
+			fmt.Printf("  %-9s %s\n", f.Op, scopePath(f.Path))
+		}
+	}
+}
+
+func printUpdatePlan(plan *updater.UpdatePlan) {
+	fmt.Println("Dry run: nothing was written.")
+	if !plan.HasUpdate {
+		fmt.Printf("\nAlready running the latest version (%s).\n", plan.CurrentVersion)
+		return
+	}
+	fmt.Printf("\n  download  %s (%s)\n", plan.Asset, formatSize(plan.Size))
+	fmt.Printf("  replace   %s (%s → %s)\n", plan.Executable, plan.CurrentVersion, plan.LatestVersion)
+}
+
+// scopePath shows a path relative to the scope directory, marking the home
+// directory with --global
+func scopePath(p string) string {
+	if flagGlobal {
+		return "~/" + p
+	}
+	return p
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	}
+	return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+}
@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// stdin is shared by every prompt so lines buffered by one are not lost to
+// the next when input is piped
+var stdin = bufio.NewReader(os.Stdin)
+
+// isInteractive reports whether stdin is a terminal that can answer prompts
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no
+func confirm(question string) (bool, error) {
+	answer, err := prompt(question + " [y/N]")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// prompt asks for a line of input and returns it trimmed
+func prompt(question string) (string, error) {
+	fmt.Printf("%s ", question)
+	answer, err := stdin.ReadString('\n')
+	if err != nil && answer == "" {
+		return "", err
+	}
+	return strings.TrimSpace(answer), nil
+}
+
+// isTerminal reports whether stdout is a terminal
+func isTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
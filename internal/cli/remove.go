@@ -2,29 +2,42 @@ package cli
 
 import (
 	"fmt"
-	"os"
+	"slices"
 
-	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/config"
 	"github.com/spf13/cobra"
 )
 
+var removeNoSave bool
+
 var removeCmd = &cobra.Command{
 	Use:     "remove [skills...]",
 	Aliases: []string{"rm", "uninstall"},
 	Short:   "Remove installed skills",
 	Long: `Remove one or more installed skills from the current project.
 
+Removed skills are also dropped from .vibe-skills.yaml (or the global config
+with --global); use --no-save to keep them listed.
+
 Examples:
   vibe-skills remove commit-convention
-  vibe-skills remove ef-core sql-optimization`,
-	Args: cobra.MinimumNArgs(1),
-	RunE: runRemove,
+  vibe-skills remove ef-core sql-optimization
+  vibe-skills remove -g commit-convention   # Remove from ~/.claude/skills
+  vibe-skills remove --no-save tdd         # Keep it in the config`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: dryRunSupported},
+	RunE:        runRemove,
+}
+
+func init() {
+	removeCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Remove from ~/.claude/skills")
+	removeCmd.Flags().BoolVar(&removeNoSave, "no-save", false, "Don't remove the skills from the config")
 }
 
 func runRemove(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := scopeDir()
 	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+		return err
 	}
 
 	reg, err := getRegistry()
@@ -32,7 +45,10 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create registry: %w", err)
 	}
 
-	inst := installer.New(reg, cwd)
+	inst, err := newInstaller(reg, cwd)
+	if err != nil {
+		return err
+	}
 
 	var removed []string
 	var errors []error
@@ -45,6 +61,19 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if flagDryRun {
+		if !removeNoSave && len(removed) > 0 {
+			_, changed, err := unsaveRemoved(cwd, removed)
+			if err != nil {
+				return fmt.Errorf("failed to update %s: %w", configName(), err)
+			}
+			if changed {
+				inst.PlanFile(configFile())
+			}
+		}
+		return reportDryRun(inst, errors)
+	}
+
 	if len(removed) > 0 {
 		fmt.Printf("Removed %d skill(s):\n", len(removed))
 		for _, name := range removed {
@@ -52,6 +81,20 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if !removeNoSave && len(removed) > 0 {
+		stacks, _, err := unsaveRemoved(cwd, removed)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", configName(), err)
+		}
+
+		// A skill declared through its stack comes back on the next install
+		for _, name := range removed {
+			if skill, err := reg.Find(name); err == nil && slices.Contains(stacks, skill.Stack) {
+				fmt.Printf("\nNote: %s is part of stack '%s' in %s and will be reinstalled by 'vibe-skills sync'\n", name, skill.Stack, configName())
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		fmt.Printf("\nFailed to remove %d skill(s):\n", len(errors))
 		for _, err := range errors {
@@ -62,3 +105,18 @@ func runRemove(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// unsaveRemoved drops removed skills from the config, returning the stacks it
+// declares and whether it changed
+func unsaveRemoved(dir string, removed []string) (stacks []string, changed bool, err error) {
+	err = editConfig(dir, func(cfgSkills *[]config.SkillEntry, cfgStacks *[]string) bool {
+		stacks = *cfgStacks
+		for _, name := range removed {
+			var dropped bool
+			*cfgSkills, dropped = config.RemoveSkill(*cfgSkills, name)
+			changed = changed || dropped
+		}
+		return changed
+	})
+	return stacks, changed, err
+}
@@ -3,8 +3,11 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/cuongtl1992/vibe-skills/internal/config"
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
 	"github.com/cuongtl1992/vibe-skills/internal/registry"
 	"github.com/spf13/cobra"
 )
@@ -14,6 +17,11 @@ var (
 	flagBranch  string
 	flagRef     string
 	flagNoCache bool
+	flagStore   bool
+	flagGlobal  bool
+	flagTargets []string
+	flagDryRun  bool
+	flagOffline bool
 )
 
 var rootCmd = &cobra.Command{
@@ -22,11 +30,19 @@ var rootCmd = &cobra.Command{
 	Long: `Vibe Skills is a community-driven collection of skills for Claude Code.
 
 Install and manage AI coding assistant skills organized by technology stack.
-Skills are installed to .claude/skills/ in your project directory.`,
+Skills are installed to .claude/skills/ in your project directory, or to
+~/.claude/skills/ for every project with --global.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkDryRun(cmd, args); err != nil {
+			return err
+		}
+		return checkOutput(cmd)
+	},
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
+		writeErrorOutput(err)
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
@@ -37,6 +53,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&flagBranch, "branch", "", "Use skills from specific branch (e.g., develop)")
 	rootCmd.PersistentFlags().StringVar(&flagRef, "ref", "", "Use skills from specific ref (branch, tag, or commit)")
 	rootCmd.PersistentFlags().BoolVar(&flagNoCache, "no-cache", false, "Skip cache and fetch fresh from registry")
+	rootCmd.PersistentFlags().BoolVar(&flagDryRun, "dry-run", false, "Resolve and fetch as usual, but print the planned changes instead of writing")
+	rootCmd.PersistentFlags().StringVarP(&flagOutput, "output", "o", "", "Output format: "+strings.Join(outputFormats, ", ")+" (see docs/output.md)")
 
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(installCmd)
@@ -46,22 +64,210 @@ func init() {
 	rootCmd.AddCommand(updateCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(selfUpdateCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(newCmd)
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(unlinkCmd)
+	rootCmd.AddCommand(storeCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(outdatedCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(browseCmd)
+	rootCmd.AddCommand(infoCmd)
 }
 
-// getRegistry creates a registry instance with resolved ref
-func getRegistry() (*registry.GitHubRegistry, error) {
+// scopeDir returns the base directory for the selected scope: the user's
+// home directory with --global, otherwise the current directory
+func scopeDir() (string, error) {
+	if flagGlobal {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		return homeDir, nil
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to get current directory: %w", err)
 	}
+	return cwd, nil
+}
 
-	// Load configs
+// loadConfigs loads the project (if present) and global configuration.
+// The project config is ignored with --global.
+func loadConfigs(dir string) (*config.Config, *config.GlobalConfig) {
 	var projectCfg *config.Config
-	if config.Exists(cwd) {
-		projectCfg, _ = config.Load(cwd)
+	if !flagGlobal && config.Exists(dir) {
+		projectCfg, _ = config.Load(dir)
 	}
 
 	globalCfg, _ := config.LoadGlobal()
+	return projectCfg, globalCfg
+}
+
+// editConfig applies edit to the skill and stack lists of the config for the
+// current scope and saves it if edit reports a change. A missing project
+// config is created. With --dry-run nothing is saved: edit still reports
+// whether the config would change.
+func editConfig(dir string, edit func(skills *[]config.SkillEntry, stacks *[]string) bool) error {
+	if flagGlobal {
+		globalCfg, err := config.LoadGlobal()
+		if err != nil {
+			return fmt.Errorf("failed to load global config: %w", err)
+		}
+		if !edit(&globalCfg.Skills, &globalCfg.Stacks) || flagDryRun {
+			return nil
+		}
+		return config.SaveGlobal(globalCfg)
+	}
+
+	cfg := &config.Config{}
+	if config.Exists(dir) {
+		var err error
+		if cfg, err = config.Load(dir); err != nil {
+			return fmt.Errorf("failed to load %s: %w", config.ConfigFileName, err)
+		}
+	}
+	if !edit(&cfg.Skills, &cfg.Stacks) || flagDryRun {
+		return nil
+	}
+	return config.Save(dir, cfg)
+}
+
+// configName is the config file editConfig writes, for messages
+func configName() string {
+	return scopePath(configFile())
+}
+
+// configFile is the config file editConfig writes, relative to scopeDir
+func configFile() string {
+	if flagGlobal {
+		return config.GlobalConfigDir + "/" + config.GlobalConfigFileName
+	}
+	return config.ConfigFileName
+}
+
+// newInstaller creates an installer honouring store mode, agent targets and
+// the CLAUDE.md skill index from flags and config
+func newInstaller(provider installer.SkillProvider, dir string) (*installer.Installer, error) {
+	projectCfg, globalCfg := loadConfigs(dir)
+
+	opts := &installer.Options{}
+	if config.ResolveStore(flagStore, projectCfg, globalCfg) {
+		opts.Store = installer.NewStore("")
+	}
+
+	names := config.ResolveTargets(flagTargets, projectCfg, globalCfg)
+	if flagGlobal {
+		// The 'targets' key of the global config is the default for projects,
+		// so only an explicit --target is worth an error
+		kept, skipped, err := globalTargets(names)
+		if err != nil {
+			return nil, err
+		}
+		if len(skipped) > 0 && len(flagTargets) > 0 {
+			return nil, fmt.Errorf("--target %s cannot be used with --global: only claude skills are installed user-wide", strings.Join(skipped, ","))
+		}
+		names = kept
+	}
+	targets, err := installer.NewTargets(names, dir)
+	if err != nil {
+		return nil, err
+	}
+	opts.Targets = targets
+
+	opts.ProviderFor = recordedProvider
+
+	switch {
+	case flagGlobal && globalCfg != nil && globalCfg.ClaudeMD:
+		opts.IndexFile = filepath.Join(dir, ".claude", installer.IndexFileName)
+	case !flagGlobal && projectCfg != nil && projectCfg.ClaudeMD:
+		opts.IndexFile = filepath.Join(dir, installer.IndexFileName)
+	}
+
+	inst := installer.NewWithOptions(provider, dir, opts)
+	inst.SetDryRun(flagDryRun)
+	return inst, nil
+}
+
+// globalTargets splits targets into those that apply to a --global install
+// and those skipped. Only the claude target has a user-wide location; the
+// other agents read their files from the project, so writing them into the
+// home directory would do nothing.
+func globalTargets(names []string) (kept, skipped []string, err error) {
+	for _, name := range names {
+		t, err := installer.NewTarget(name, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		if t.Name() == installer.TargetClaude {
+			kept = append(kept, name)
+		} else {
+			skipped = append(skipped, name)
+		}
+	}
+	return kept, skipped, nil
+}
+
+// recordedProvider creates a registry for the source and ref a skill was
+// installed from. Explicit --branch/--ref flags still take precedence.
+func recordedProvider(entry *installer.ManifestEntry) (installer.SkillProvider, error) {
+	ref := entry.Ref
+	if flagRef != "" || flagBranch != "" {
+		ref = config.ResolveRef(flagBranch, flagRef, nil, nil)
+	}
+	return registryAt(entry.Source, ref)
+}
+
+// registries memoizes registryAt so the index and commit of a source are
+// fetched once per run rather than once per skill
+var registries = make(map[string]*registry.GitHubRegistry)
+
+// registryAt returns a registry for a source (github.com/owner/repo, or the
+// default registry when empty) at a ref
+func registryAt(source, ref string) (*registry.GitHubRegistry, error) {
+	key := source + "@" + ref
+	if reg, ok := registries[key]; ok {
+		return reg, nil
+	}
+
+	var owner, repo string
+	if source != "" {
+		var err error
+		owner, repo, err = registry.ParseSource(source)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reg := registry.NewGitHubRegistry(&registry.GitHubRegistryOptions{
+		Owner:   owner,
+		Repo:    repo,
+		Ref:     ref,
+		NoCache: flagNoCache,
+		Offline: flagOffline,
+	})
+	registries[key] = reg
+	return reg, nil
+}
+
+// addTargetFlag registers --target on a command
+func addTargetFlag(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&flagTargets, "target", nil, "Agent formats to keep in sync: "+strings.Join(installer.TargetNames, ","))
+}
+
+// getRegistry creates a registry instance with resolved ref
+func getRegistry() (*registry.GitHubRegistry, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load configs
+	projectCfg, globalCfg := loadConfigs(cwd)
 
 	// Resolve ref with priority
 	ref := config.ResolveRef(flagBranch, flagRef, projectCfg, globalCfg)
@@ -69,5 +275,6 @@ func getRegistry() (*registry.GitHubRegistry, error) {
 	return registry.NewGitHubRegistry(&registry.GitHubRegistryOptions{
 		Ref:     ref,
 		NoCache: flagNoCache,
+		Offline: flagOffline,
 	}), nil
 }
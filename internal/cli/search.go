@@ -3,21 +3,42 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/search"
 	"github.com/spf13/cobra"
 )
 
+var searchLimit int
+
 var searchCmd = &cobra.Command{
 	Use:   "search [query]",
 	Short: "Search for skills",
-	Long: `Search for skills by name or description.
+	Long: `Search skills by name, description and content, best matches first.
+
+Every file of every skill is searched, not just the description, so a query
+finds the skill whose reference covers it. Quote words to search for an exact
+phrase; skills must contain every phrase and rank higher the more of the other
+words they contain.
+
+The first search downloads the registry's skill files into a local index
+(~/.vibe-skills/cache). Later searches only download files that changed, and
+--offline searches what was indexed before without touching the network.
 
 Examples:
   vibe-skills search database
-  vibe-skills search "code review"`,
-	Args: cobra.ExactArgs(1),
-	RunE: runSearch,
+  vibe-skills search deadlock
+  vibe-skills search '"code review" security'
+  vibe-skills search review -o json`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{dryRunAnnotation: dryRunReadOnly, outputAnnotation: outputSupported},
+	RunE:        runSearch,
+}
+
+func init() {
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 20, "Maximum number of results (0 for all)")
+	searchCmd.Flags().BoolVar(&flagOffline, "offline", false, "Search the cached registry index and skill files only")
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
@@ -35,17 +56,56 @@ func runSearch(cmd *cobra.Command, args []string) error {
 
 	inst := installer.New(reg, cwd)
 
-	results, err := reg.Search(query)
+	all, err := reg.List()
 	if err != nil {
 		return fmt.Errorf("failed to search skills: %w", err)
 	}
+	cmd.SilenceUsage = true
+
+	index, stats := search.Refresh(search.StorePath(reg.Source()), all, reg, search.RefreshOptions{
+		Offline: flagOffline,
+		Fetching: func(n int) {
+			fmt.Fprintf(os.Stderr, "Indexing %d file(s) from registry (%s)...\n", n, reg.GetRef())
+		},
+	})
+	if stats.Failed > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d file(s) could not be downloaded, results may be incomplete\n", stats.Failed)
+	}
+
+	results := index.Search(query, searchLimit)
+	skills := make(map[string]int)
+	for i, s := range all {
+		skills[s.Stack+"/"+s.Name] = i
+	}
+
+	if machineOutput() {
+		rows := []searchRow{}
+		for _, r := range results {
+			s := all[skills[r.Stack+"/"+r.Name]]
+			rows = append(rows, searchRow{
+				skillRow: skillRow{
+					Stack:       s.Stack,
+					Name:        s.Name,
+					Description: s.Description,
+					Version:     s.Version,
+					Installed:   installedIn(inst, nil, s.Name),
+				},
+				Score:   r.Score,
+				File:    r.File,
+				Snippet: r.Snippet,
+			})
+		}
+		return writeOutput(rows)
+	}
 	if len(results) == 0 {
 		fmt.Printf("No skills found matching: %s\n", query)
 		return nil
 	}
 
+	color := useColor()
 	fmt.Printf("Found %d skill(s) matching '%s':\n\n", len(results), query)
-	for _, skill := range results {
+	for _, r := range results {
+		skill := all[skills[r.Stack+"/"+r.Name]]
 		installed := ""
 		if inst.IsInstalled(skill.Name) {
 			installed = " [installed]"
@@ -54,8 +114,23 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		if skill.Description != "" {
 			fmt.Printf("    %s\n", skill.Description)
 		}
+		if r.Snippet != "" {
+			snippet := r.Snippet
+			if color {
+				snippet = r.Highlight(ansiBold+ansiYellow, ansiReset)
+			}
+			fmt.Printf("    %s: %s\n", colorize(r.File, ansiCyan, color), strings.TrimSpace(snippet))
+		}
 		fmt.Println()
 	}
 
 	return nil
 }
+
+// searchRow is the --output form of a search result
+type searchRow struct {
+	skillRow
+	Score   float64 `json:"score"`
+	File    string  `json:"file"`    // File the snippet comes from
+	Snippet string  `json:"snippet"` // Empty when only the name or description matched
+}
@@ -9,16 +9,26 @@ import (
 )
 
 var selfUpdateCmd = &cobra.Command{
-	Use:   "self-update",
-	Short: "Update vibe-skills to the latest version",
-	Long:  `Downloads and installs the latest version of vibe-skills from GitHub releases.`,
-	RunE:  runSelfUpdate,
+	Use:         "self-update",
+	Short:       "Update vibe-skills to the latest version",
+	Long:        `Downloads and installs the latest version of vibe-skills from GitHub releases.`,
+	Annotations: map[string]string{dryRunAnnotation: dryRunSupported},
+	RunE:        runSelfUpdate,
 }
 
 func runSelfUpdate(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Current version: %s\n", version.GetVersion())
 	fmt.Println("Checking for updates...")
 
+	if flagDryRun {
+		plan, err := updater.Plan()
+		if err != nil {
+			return fmt.Errorf("failed to check for updates: %w", err)
+		}
+		printUpdatePlan(plan)
+		return nil
+	}
+
 	latestVersion, hasUpdate, err := updater.CheckForUpdate()
 	if err != nil {
 		return fmt.Errorf("failed to check for updates: %w", err)
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/cuongtl1992/vibe-skills/internal/diff"
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statusDiff     bool
+	statusExitCode bool
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status [skill-names...]",
+	Short: "Show local modifications to installed skills",
+	Long: `Compare installed skills against the file hashes recorded when they were
+installed and report modified, added and deleted files.
+
+Examples:
+  vibe-skills status
+  vibe-skills status code-reviewer --diff
+  vibe-skills status --json
+  vibe-skills status --exit-code    # Exit 1 if any skill has local changes`,
+	Annotations: map[string]string{dryRunAnnotation: dryRunReadOnly, outputAnnotation: outputSupported},
+	RunE:        runStatus,
+}
+
+func init() {
+	statusCmd.Flags().BoolVar(&statusDiff, "diff", false, "Print unified diffs against the installed version")
+	statusCmd.Flags().BoolVar(&flagJSON, "json", false, "Output results as JSON (same as --output json)")
+	statusCmd.Flags().BoolVar(&statusExitCode, "exit-code", false, "Exit with status 1 when any skill has drifted")
+	statusCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Check skills in ~/.claude/skills")
+}
+
+// statusReport is the JSON form of a skill's status
+type statusReport struct {
+	*installer.SkillStatus
+	Diffs map[string]string `json:"diffs,omitempty"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	cwd, err := scopeDir()
+	if err != nil {
+		return err
+	}
+
+	inst, err := newInstaller(nil, cwd)
+	if err != nil {
+		return err
+	}
+
+	var statuses []*installer.SkillStatus
+	if len(args) == 0 {
+		statuses, err = inst.StatusAll()
+		if err != nil {
+			return fmt.Errorf("failed to check installed skills: %w", err)
+		}
+	} else {
+		for _, name := range args {
+			s, err := inst.Status(name)
+			if err != nil {
+				return err
+			}
+			statuses = append(statuses, s)
+		}
+	}
+
+	cmd.SilenceUsage = true
+
+	drifted := 0
+	var reports []statusReport
+	for _, s := range statuses {
+		report := statusReport{SkillStatus: s}
+		if s.Drifted() {
+			drifted++
+			if statusDiff {
+				report.Diffs, err = skillDiffs(inst, s)
+				if err != nil {
+					return fmt.Errorf("%s: %w", s.Name, err)
+				}
+			}
+		}
+		reports = append(reports, report)
+	}
+
+	if machineOutput() {
+		if reports == nil {
+			reports = []statusReport{}
+		}
+		if err := writeOutput(reports); err != nil {
+			return err
+		}
+	} else {
+		printStatus(reports)
+	}
+
+	if statusExitCode && drifted > 0 {
+		return fmt.Errorf("%d skill(s) have local modifications", drifted)
+	}
+	return nil
+}
+
+func printStatus(reports []statusReport) {
+	if len(reports) == 0 {
+		fmt.Println("No skills installed.")
+		return
+	}
+
+	for _, r := range reports {
+		fmt.Printf("  %-25s %s\n", r.Name, r.State)
+		for _, f := range r.Modified {
+			fmt.Printf("      modified:  %s\n", f)
+		}
+		for _, f := range r.Added {
+			fmt.Printf("      added:     %s\n", f)
+		}
+		for _, f := range r.Deleted {
+			fmt.Printf("      deleted:   %s\n", f)
+		}
+	}
+
+	for _, r := range reports {
+		paths := make([]string, 0, len(r.Diffs))
+		for p := range r.Diffs {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		for _, p := range paths {
+			fmt.Println()
+			fmt.Print(r.Diffs[p])
+		}
+	}
+}
+
+// skillDiffs diffs each changed file of a skill against its installed version
+func skillDiffs(inst *installer.Installer, s *installer.SkillStatus) (map[string]string, error) {
+	base, err := inst.FetchInstalledVersion(s.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch installed version: %w", err)
+	}
+	local, err := inst.ReadSkillFiles(s.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := append(append(append([]string{}, s.Modified...), s.Added...), s.Deleted...)
+	return fileDiffs(s.Name, base, local, changed), nil
+}
+
+// fileDiffs renders a unified diff per file, naming files a/<skill>/<file>
+// and b/<skill>/<file> so the output can be saved as an overlay patch
+func fileDiffs(skill string, from, to map[string][]byte, files []string) map[string]string {
+	diffs := make(map[string]string)
+	for _, f := range files {
+		a, b := path.Join("a", skill, f), path.Join("b", skill, f)
+		if _, ok := from[f]; !ok {
+			a = diff.DevNull
+		}
+		if _, ok := to[f]; !ok {
+			b = diff.DevNull
+		}
+		if d := diff.Unified(a, b, from[f], to[f], diff.DefaultContext); d != "" {
+			diffs[f] = d
+		}
+	}
+	return diffs
+}
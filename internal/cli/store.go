@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/spf13/cobra"
+)
+
+var storePruneDryRun bool
+
+var storeCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Manage the shared skill store",
+	Long: `Manage the shared skill store in ~/.vibe-skills/store.
+
+In store mode (--store, or 'store: true' in .vibe-skills.yaml or the global
+config) each skill version is stored once and projects link to it, so
+installs are instant and an update shows up in every project tracking the
+same registry ref.`,
+}
+
+var storePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove stored skill versions no project references",
+	Long: `Garbage-collect the shared store. Every project that installed skills in
+store mode is scanned; versions and ref channels none of them link to are
+deleted. Projects that no longer exist are forgotten.
+
+Examples:
+  vibe-skills store prune
+  vibe-skills store prune --list   # Show what would be removed`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{dryRunAnnotation: dryRunSupported},
+	RunE:        runStorePrune,
+}
+
+func init() {
+	storePruneCmd.Flags().BoolVar(&storePruneDryRun, "list", false, "List unreferenced entries without deleting them")
+	storeCmd.AddCommand(storePruneCmd)
+}
+
+func runStorePrune(cmd *cobra.Command, args []string) error {
+	store := installer.NewStore("")
+
+	dryRun := storePruneDryRun || flagDryRun
+	result, err := store.Prune(dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to prune store: %w", err)
+	}
+
+	if len(result.Removed) == 0 {
+		fmt.Printf("Store is clean (%d project(s) referencing %s)\n", result.Projects, store.Dir())
+		return nil
+	}
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Unreferenced"
+	}
+	fmt.Printf("%s %d store entr(ies):\n", verb, len(result.Removed))
+	for _, p := range result.Removed {
+		fmt.Printf("  ✓ %s\n", p)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/config"
+	"github.com/cuongtl1992/vibe-skills/internal/installer"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncPrune bool
+	syncYes   bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Make installed skills match the config file",
+	Long: `Reconcile .claude/skills with the skills declared in .vibe-skills.yaml
+(or in ~/.vibe-skills/config.yaml with --global): install missing skills and
+update outdated ones. With --prune, installed skills that are not declared
+are removed. Linked skills are left alone.
+
+The plan is printed first and applied after confirmation.
+
+Examples:
+  vibe-skills sync
+  vibe-skills sync --prune
+  vibe-skills sync --prune --yes    # Non-interactive, e.g. in CI or scripts
+  vibe-skills sync --global`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{dryRunAnnotation: dryRunSupported},
+	RunE:        runSync,
+}
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncPrune, "prune", false, "Remove installed skills that are not declared")
+	syncCmd.Flags().BoolVarP(&syncYes, "yes", "y", false, "Apply the plan without asking")
+	syncCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Sync ~/.claude/skills with the global config")
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	cwd, err := scopeDir()
+	if err != nil {
+		return err
+	}
+
+	reg, err := getRegistry()
+	if err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+
+	declared, err := declaredSkills(cwd, reg)
+	if err != nil {
+		return err
+	}
+
+	inst, err := newInstaller(reg, cwd)
+	if err != nil {
+		return err
+	}
+
+	cmd.SilenceUsage = true
+
+	plan, err := inst.PlanSync(declared, syncPrune)
+	if err != nil {
+		return err
+	}
+
+	printSyncPlan(plan)
+	if plan.Empty() {
+		return nil
+	}
+
+	if flagDryRun {
+		fmt.Println()
+		_, errors := inst.ApplySync(plan)
+		return reportDryRun(inst, errors)
+	}
+
+	if !syncYes {
+		if !isInteractive() {
+			return fmt.Errorf("not applying the plan without confirmation: re-run with --yes")
+		}
+		ok, err := confirm("\nApply these changes?")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Aborted.")
+			return nil
+		}
+	}
+
+	fmt.Println()
+	done, errors := inst.ApplySync(plan)
+	for _, a := range done {
+		fmt.Printf("  ✓ %s %s\n", a.Kind, a.Skill)
+	}
+	for _, err := range errors {
+		fmt.Printf("  ✗ %s\n", err)
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%d of %d change(s) failed", len(errors), len(plan.Actions))
+	}
+	fmt.Printf("\nApplied %d change(s)\n", len(done))
+	return nil
+}
+
+// declaredSkills returns the enabled skills listed in the config for the
+// current scope, including every skill of the listed stacks
+func declaredSkills(dir string, reg registry.Registry) ([]installer.Spec, error) {
+	var projectCfg *config.Config
+	var globalCfg *config.GlobalConfig
+	var entries []config.SkillEntry
+	var stacks []string
+	var err error
+	if flagGlobal {
+		if globalCfg, err = config.LoadGlobal(); err != nil {
+			return nil, fmt.Errorf("failed to load global config: %w", err)
+		}
+		entries, stacks = globalCfg.Skills, globalCfg.Stacks
+	} else {
+		if projectCfg, err = config.Load(dir); err != nil {
+			if config.Exists(dir) {
+				return nil, fmt.Errorf("failed to load %s: %w", config.ConfigFileName, err)
+			}
+			return nil, fmt.Errorf("no config file found: run 'vibe-skills init' to create %s", config.ConfigFileName)
+		}
+		globalCfg, _ = config.LoadGlobal()
+		entries, stacks = projectCfg.Skills, projectCfg.Stacks
+	}
+
+	var specs []installer.Spec
+	for _, e := range entries {
+		if !e.IsEnabled() {
+			continue
+		}
+		provider, err := registryAt(e.Source, config.ResolveSkillRef(e, flagBranch, flagRef, projectCfg, globalCfg))
+		if err != nil {
+			return nil, fmt.Errorf("skill %s: %w", e.Name, err)
+		}
+		targets := e.Target
+		if flagGlobal {
+			kept, skipped, err := globalTargets(targets)
+			if err != nil {
+				return nil, fmt.Errorf("skill %s: %w", e.Name, err)
+			}
+			if len(skipped) > 0 {
+				fmt.Fprintf(os.Stderr, "Warning: skill %s: skipping target %s, only claude applies with --global\n", e.Name, strings.Join(skipped, ", "))
+			}
+			targets = kept
+		}
+		specs = append(specs, installer.Spec{
+			Name:     e.Name,
+			Provider: provider,
+			Version:  e.Version,
+			Targets:  targets,
+			With:     e.With,
+		})
+	}
+
+	for _, stack := range stacks {
+		stackSkills, err := reg.ListByStack(stack)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stack %s: %w", stack, err)
+		}
+		for _, skill := range stackSkills {
+			specs = append(specs, installer.Spec{Name: skill.Stack + "/" + skill.Name})
+		}
+	}
+	return specs, nil
+}
+
+func printSyncPlan(plan *installer.SyncPlan) {
+	if plan.Empty() {
+		fmt.Printf("Already in sync (%d skill(s)).\n", len(plan.Unchanged))
+		printExtra(plan.Extra)
+		return
+	}
+
+	fmt.Println("Plan:")
+	for _, a := range plan.Actions {
+		symbol := map[string]string{
+			installer.ActionInstall: "+",
+			installer.ActionUpdate:  "~",
+			installer.ActionRemove:  "-",
+		}[a.Kind]
+		if a.Detail != "" {
+			fmt.Printf("  %s %-8s %-25s %s\n", symbol, a.Kind, a.Skill, a.Detail)
+		} else {
+			fmt.Printf("  %s %-8s %s\n", symbol, a.Kind, a.Skill)
+		}
+	}
+	if len(plan.Unchanged) > 0 {
+		fmt.Printf("\n%d skill(s) already up to date.\n", len(plan.Unchanged))
+	}
+	printExtra(plan.Extra)
+}
+
+func printExtra(extra []string) {
+	if len(extra) == 0 {
+		return
+	}
+	if syncPrune {
+		fmt.Printf("\nNot declared (linked, so kept):\n")
+	} else {
+		fmt.Printf("\nNot declared (kept; use --prune to remove):\n")
+	}
+	for _, name := range extra {
+		fmt.Printf("  %s\n", name)
+	}
+}
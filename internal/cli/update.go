@@ -2,72 +2,168 @@ package cli
 
 import (
 	"fmt"
-	"os"
+	"sort"
+	"strings"
 
 	"github.com/cuongtl1992/vibe-skills/internal/installer"
 	"github.com/spf13/cobra"
 )
 
+var (
+	updateStrategy  string
+	updateConflicts bool
+)
+
 var updateCmd = &cobra.Command{
 	Use:   "update [skill-names...]",
 	Short: "Update installed skills to latest version",
 	Long: `Update installed skills to their latest version from the registry.
 
+Local edits are kept: each file is three-way merged between the version that
+was installed, your copy and the new upstream version. Conflicting changes
+are handled by --strategy:
+
+  merge   Leave conflict markers in the file and report it (default)
+  ours    Keep your side of each conflicting change
+  theirs  Take the upstream side of each conflicting change
+
 Examples:
   # Update all installed skills
   vibe-skills update
 
   # Update specific skill(s)
   vibe-skills update code-reviewer
-  vibe-skills update code-reviewer sqlserver-expert`,
-	RunE: runUpdate,
+  vibe-skills update code-reviewer sqlserver-expert
+
+  # Update skills in ~/.claude/skills
+  vibe-skills update --global
+
+  # Prefer upstream where local edits conflict
+  vibe-skills update code-reviewer --strategy theirs
+
+  # List conflicts left by previous updates
+  vibe-skills update --conflicts`,
+	Annotations: map[string]string{dryRunAnnotation: dryRunSupported, outputAnnotation: outputSupported},
+	RunE:        runUpdate,
+}
+
+func init() {
+	updateCmd.Flags().BoolVarP(&flagGlobal, "global", "g", false, "Update skills in ~/.claude/skills")
+	addTargetFlag(updateCmd)
+	updateCmd.Flags().BoolVar(&flagStore, "store", false, "Store skills once in ~/.vibe-skills/store and link them into the project")
+	updateCmd.Flags().StringVar(&updateStrategy, "strategy", installer.StrategyMerge, "How to handle conflicting local edits: "+strings.Join(installer.Strategies, ", "))
+	updateCmd.Flags().BoolVar(&updateConflicts, "conflicts", false, "List unresolved conflicts instead of updating")
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
-	reg, err := getRegistry()
+	if !installer.ValidStrategy(updateStrategy) {
+		return fmt.Errorf("unknown strategy %q (use %s)", updateStrategy, strings.Join(installer.Strategies, ", "))
+	}
+
+	cwd, err := scopeDir()
 	if err != nil {
 		return err
 	}
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
+	if updateConflicts {
+		inst, err := newInstaller(nil, cwd)
+		if err != nil {
+			return err
+		}
+		cmd.SilenceUsage = true
+		return listConflicts(inst)
 	}
 
-	inst := installer.New(reg, cwd)
+	reg, err := getRegistry()
+	if err != nil {
+		return err
+	}
 
-	var updated []string
-	var errors []error
+	inst, err := newInstaller(reg, cwd)
+	if err != nil {
+		return err
+	}
+	inst.SetStrategy(updateStrategy)
 
-	if len(args) == 0 {
+	names := args
+	if len(names) == 0 {
 		// Update all installed skills
-		installed, err := inst.ListInstalled()
+		names, err = inst.ListInstalled()
 		if err != nil {
 			return fmt.Errorf("failed to list installed skills: %w", err)
 		}
 
-		if len(installed) == 0 {
+		if len(names) == 0 {
+			if machineOutput() {
+				return writeOutput(updateReport{Updated: []updateRow{}, Errors: []outputError{}})
+			}
 			fmt.Println("No skills installed to update")
 			return nil
 		}
 
-		fmt.Printf("Updating %d installed skill(s)...\n", len(installed))
-		updated, errors = inst.UpdateAll()
+		fmt.Printf("Updating %d installed skill(s)...\n", len(names))
 	} else {
 		// Update specific skills
-		fmt.Printf("Updating %d skill(s)...\n", len(args))
-		for _, name := range args {
-			if err := inst.Update(name); err != nil {
-				errors = append(errors, fmt.Errorf("%s: %w", name, err))
-			} else {
-				updated = append(updated, name)
-			}
+		fmt.Printf("Updating %d skill(s)...\n", len(names))
+	}
+
+	var results []*installer.UpdateResult
+	var errors []error
+	for _, name := range names {
+		result, err := inst.UpdateSkill(name)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%s: %w", name, err))
+		} else {
+			results = append(results, result)
 		}
 	}
 
+	if flagDryRun {
+		return reportDryRun(inst, errors)
+	}
+
+	if machineOutput() {
+		report := updateReport{Updated: []updateRow{}, Errors: outputErrors(errors)}
+		for _, r := range results {
+			report.Updated = append(report.Updated, newUpdateRow(inst, r))
+		}
+		if err := writeOutput(report); err != nil {
+			return err
+		}
+		if len(errors) > 0 {
+			return fmt.Errorf("failed to update %d skill(s)", len(errors))
+		}
+		return nil
+	}
+
 	// Print results
-	for _, name := range updated {
-		fmt.Printf("  ✓ %s\n", name)
+	conflicts, upToDate := 0, 0
+	for _, r := range results {
+		switch {
+		case inst.IsLinked(r.Name):
+			fmt.Printf("  ✓ %s (linked)\n", r.Name)
+		case r.UpToDate:
+			fmt.Printf("  = %s (up to date)\n", r.Name)
+			upToDate++
+		case len(r.Conflicts) > 0:
+			fmt.Printf("  ! %s (%d conflict(s))\n", r.Name, len(r.Conflicts))
+		case len(r.Merged)+len(r.Resolved) > 0:
+			fmt.Printf("  ✓ %s (local edits kept)\n", r.Name)
+		case len(r.Changed) > 0:
+			fmt.Printf("  ✓ %s (%d file(s) changed)\n", r.Name, len(r.Changed))
+		default:
+			fmt.Printf("  ✓ %s\n", r.Name)
+		}
+		for _, f := range r.Merged {
+			fmt.Printf("      merged:    %s\n", f)
+		}
+		for _, f := range r.Resolved {
+			fmt.Printf("      resolved:  %s (%s)\n", f, updateStrategy)
+		}
+		for _, c := range r.Conflicts {
+			fmt.Printf("      conflict:  %s (%s)\n", c.File, c.Reason)
+		}
+		conflicts += len(r.Conflicts)
 	}
 	for _, err := range errors {
 		fmt.Printf("  ✗ %s\n", err)
@@ -77,8 +173,108 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to update %d skill(s)", len(errors))
 	}
 
-	if len(updated) > 0 {
-		fmt.Printf("\nUpdated %d skill(s)\n", len(updated))
+	if updated := len(results) - upToDate; updated > 0 || upToDate > 0 {
+		fmt.Printf("\nUpdated %d skill(s), %d already up to date\n", updated, upToDate)
+	}
+	if conflicts > 0 {
+		fmt.Printf("\n%d file(s) have conflicts. Edit them to resolve the markers, or re-run with --strategy ours|theirs.\n", conflicts)
+		fmt.Println("Run 'vibe-skills update --conflicts' to list what is still unresolved.")
 	}
 	return nil
 }
+
+// Update states in reports
+const (
+	updateUpdated  = "updated"
+	updateUpToDate = "up_to_date"
+	updateConflict = "conflicts"
+	updateLinked   = "linked"
+)
+
+// updateReport is the --output form of update
+type updateReport struct {
+	Updated []updateRow   `json:"updated"`
+	Errors  []outputError `json:"errors"`
+}
+
+type updateRow struct {
+	Name      string               `json:"name"`
+	State     string               `json:"state"`
+	Changed   []string             `json:"changed"`
+	Merged    []string             `json:"merged"`
+	Resolved  []string             `json:"resolved"`
+	Conflicts []installer.Conflict `json:"conflicts"`
+}
+
+func newUpdateRow(inst *installer.Installer, r *installer.UpdateResult) updateRow {
+	row := updateRow{
+		Name:      r.Name,
+		State:     updateUpdated,
+		Changed:   orEmpty(r.Changed),
+		Merged:    orEmpty(r.Merged),
+		Resolved:  orEmpty(r.Resolved),
+		Conflicts: orEmpty(r.Conflicts),
+	}
+	switch {
+	case inst.IsLinked(r.Name):
+		row.State = updateLinked
+	case r.UpToDate:
+		row.State = updateUpToDate
+	case len(r.Conflicts) > 0:
+		row.State = updateConflict
+	}
+	return row
+}
+
+// conflictRow is the --output form of update --conflicts
+type conflictRow struct {
+	Skill  string `json:"skill"`
+	File   string `json:"file"`
+	Reason string `json:"reason"`
+}
+
+// listConflicts prints the conflicts left by previous merge updates
+func listConflicts(inst *installer.Installer) error {
+	conflicts, err := inst.Conflicts()
+	if err != nil {
+		return fmt.Errorf("failed to read conflicts: %w", err)
+	}
+	if machineOutput() {
+		rows := []conflictRow{}
+		for _, name := range sortedKeys(conflicts) {
+			for _, c := range conflicts[name] {
+				rows = append(rows, conflictRow{Skill: name, File: c.File, Reason: c.Reason})
+			}
+		}
+		if err := writeOutput(rows); err != nil {
+			return err
+		}
+		if len(rows) > 0 {
+			return fmt.Errorf("%d unresolved conflict(s)", len(rows))
+		}
+		return nil
+	}
+	if len(conflicts) == 0 {
+		fmt.Println("No unresolved conflicts.")
+		return nil
+	}
+
+	total := 0
+	for _, name := range sortedKeys(conflicts) {
+		fmt.Printf("  %s\n", name)
+		for _, c := range conflicts[name] {
+			fmt.Printf("      %-30s %s\n", c.File, c.Reason)
+			total++
+		}
+	}
+	return fmt.Errorf("%d unresolved conflict(s)", total)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
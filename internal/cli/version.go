@@ -8,9 +8,25 @@ import (
 )
 
 var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "Print version information",
-	Run: func(cmd *cobra.Command, args []string) {
+	Use:         "version",
+	Short:       "Print version information",
+	Annotations: map[string]string{dryRunAnnotation: dryRunReadOnly, outputAnnotation: outputSupported},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if machineOutput() {
+			return writeOutput(versionReport{
+				Version: version.Version,
+				Commit:  version.Commit,
+				Date:    version.Date,
+			})
+		}
 		fmt.Printf("vibe-skills %s\n", version.GetFullVersion())
+		return nil
 	},
 }
+
+// versionReport is the --output form of version
+type versionReport struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+	Date    string `json:"date"`
+}
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -21,16 +24,28 @@ type RegistryConfig struct {
 
 // Config represents the project-level configuration
 type Config struct {
+	Version  int             `yaml:"version,omitempty"` // Schema version, see SchemaVersion
 	Registry *RegistryConfig `yaml:"registry,omitempty"`
-	Skills   []string        `yaml:"skills"`
+	Store    *bool           `yaml:"store,omitempty"`     // Link skills from the shared store
+	Targets  []string        `yaml:"targets,omitempty"`   // Agent formats to install (claude, cursor, copilot, codex)
+	ClaudeMD bool            `yaml:"claude_md,omitempty"` // Keep a skill index block in CLAUDE.md
+	Stacks   []string        `yaml:"stacks,omitempty"`    // Every skill of these stacks is declared
+	Skills   []SkillEntry    `yaml:"skills"`
 }
 
 // GlobalConfig represents user-level configuration
 type GlobalConfig struct {
+	Version  int             `yaml:"version,omitempty"` // Schema version, see SchemaVersion
 	Registry *RegistryConfig `yaml:"registry,omitempty"`
+	Store    bool            `yaml:"store,omitempty"`     // Default store mode for all projects
+	Targets  []string        `yaml:"targets,omitempty"`   // Default agent formats for all projects
+	ClaudeMD bool            `yaml:"claude_md,omitempty"` // Keep a skill index block in ~/.claude/CLAUDE.md
+	Stacks   []string        `yaml:"stacks,omitempty"`    // Stacks installed to ~/.claude/skills
+	Skills   []SkillEntry    `yaml:"skills,omitempty"`    // Skills installed to ~/.claude/skills
 }
 
-// Load loads project configuration from the specified directory
+// Load loads project configuration from the specified directory. Version 1
+// files are migrated in memory; Save writes them back in the minimal form.
 func Load(dir string) (*Config, error) {
 	path := filepath.Join(dir, ConfigFileName)
 	data, err := os.ReadFile(path)
@@ -42,19 +57,21 @@ func Load(dir string) (*Config, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, err
 	}
+	if err := checkVersion(cfg.Version, ConfigFileName); err != nil {
+		return nil, err
+	}
+	cfg.Version = SchemaVersion
 
 	return &cfg, nil
 }
 
-// Save saves project configuration to the specified directory
+// Save saves project configuration to the specified directory, writing
+// skills as plain strings where possible. An existing file keeps its
+// comments and key order, see writeConfig.
 func Save(dir string, cfg *Config) error {
-	path := filepath.Join(dir, ConfigFileName)
-	data, err := yaml.Marshal(cfg)
-	if err != nil {
-		return err
-	}
-
-	return os.WriteFile(path, data, 0644)
+	out := *cfg
+	out.Version = minimalVersion(cfg.Skills)
+	return writeConfig(filepath.Join(dir, ConfigFileName), &out)
 }
 
 // Exists checks if project configuration exists
@@ -67,9 +84,7 @@ func Exists(dir string) bool {
 // GetDefaultConfig returns default project configuration
 func GetDefaultConfig() *Config {
 	return &Config{
-		Skills: []string{
-			"common/code-reviewer",
-		},
+		Skills: Skills("common/code-reviewer"),
 	}
 }
 
@@ -93,6 +108,10 @@ func LoadGlobal() (*GlobalConfig, error) {
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, err
 	}
+	if err := checkVersion(cfg.Version, "~/"+GlobalConfigDir+"/"+GlobalConfigFileName); err != nil {
+		return nil, err
+	}
+	cfg.Version = SchemaVersion
 
 	return &cfg, nil
 }
@@ -109,13 +128,162 @@ func SaveGlobal(cfg *GlobalConfig) error {
 		return err
 	}
 
-	path := filepath.Join(dir, GlobalConfigFileName)
-	data, err := yaml.Marshal(cfg)
-	if err != nil {
+	out := *cfg
+	out.Version = minimalVersion(cfg.Skills)
+	return writeConfig(filepath.Join(dir, GlobalConfigFileName), &out)
+}
+
+// writeConfig writes cfg to path. When the file already holds a config, its
+// document is edited in place rather than replaced: values that did not
+// change keep their nodes, and with them their comments and position, and
+// only what changed is re-encoded. A list such as 'skills' keeps its
+// unchanged items, so adding a skill appends one and removing one drops it.
+func writeConfig(path string, cfg interface{}) error {
+	var fresh yaml.Node
+	if err := fresh.Encode(cfg); err != nil {
 		return err
 	}
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&fresh}}
+	indent := 4 // yaml.Marshal's
+
+	if data, err := os.ReadFile(path); err == nil {
+		var old yaml.Node
+		if yaml.Unmarshal(data, &old) == nil && len(old.Content) == 1 && old.Content[0].Kind == yaml.MappingNode {
+			old.Content[0] = mergeNode(old.Content[0], &fresh)
+			doc = &old
+			indent = detectIndent(data, indent)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// mergeNode returns the node to write for a value that was old and is now
+// fresh: old itself when the value is unchanged, otherwise fresh with the
+// unchanged parts of old (mapping keys and list items) reused
+func mergeNode(old, fresh *yaml.Node) *yaml.Node {
+	if sameValue(old, fresh) {
+		return old
+	}
+
+	switch {
+	case old.Kind == yaml.MappingNode && fresh.Kind == yaml.MappingNode:
+		merged := *old
+		merged.Content = nil
+		used := make(map[string]bool)
+		for n := 0; n+1 < len(old.Content); n += 2 {
+			key := old.Content[n]
+			if value := mappingValue(fresh, key.Value); value != nil {
+				value = mergeNode(old.Content[n+1], value)
+				if value.Kind != yaml.ScalarNode && value.Style&yaml.FlowStyle == 0 && value.LineComment != "" {
+					// A block value cannot carry a line comment; its key can
+					moved := *key
+					moved.LineComment, value.LineComment = value.LineComment, ""
+					key = &moved
+				}
+				merged.Content = append(merged.Content, key, value)
+				used[key.Value] = true
+			}
+		}
+		for n := 0; n+1 < len(fresh.Content); n += 2 {
+			if !used[fresh.Content[n].Value] {
+				merged.Content = append(merged.Content, fresh.Content[n], fresh.Content[n+1])
+			}
+		}
+		return &merged
+
+	case old.Kind == yaml.SequenceNode && fresh.Kind == yaml.SequenceNode:
+		merged := *old
+		merged.Content = nil
+		if len(old.Content) == 0 {
+			merged.Style = fresh.Style // Grow "[]" into a block list
+		}
+		used := make([]bool, len(old.Content))
+		for _, item := range fresh.Content {
+			kept := item
+			for n, o := range old.Content {
+				if !used[n] && sameValue(o, item) {
+					kept, used[n] = o, true
+					break
+				}
+			}
+			merged.Content = append(merged.Content, kept)
+		}
+		return &merged
+	}
+
+	// A changed scalar, or a value that changed shape: keep its comments
+	fresh.HeadComment, fresh.LineComment, fresh.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	return fresh
+}
+
+// detectIndent returns the indentation of the first indented line of a YAML
+// file, or def when there is none
+func detectIndent(data []byte, def int) int {
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed == line || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if n := len(line) - len(trimmed); n >= 2 && n <= 8 {
+			return n
+		}
+		return def
+	}
+	return def
+}
 
-	return os.WriteFile(path, data, 0644)
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for n := 0; n+1 < len(mapping.Content); n += 2 {
+		if mapping.Content[n].Value == key {
+			return mapping.Content[n+1]
+		}
+	}
+	return nil
+}
+
+// sameValue reports whether two nodes decode to the same data
+func sameValue(a, b *yaml.Node) bool {
+	var va, vb interface{}
+	if a.Decode(&va) != nil || b.Decode(&vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// ResolveStore resolves store mode with priority: flag > project > global
+func ResolveStore(flagStore bool, projectCfg *Config, globalCfg *GlobalConfig) bool {
+	if flagStore {
+		return true
+	}
+	if projectCfg != nil && projectCfg.Store != nil {
+		return *projectCfg.Store
+	}
+	return globalCfg != nil && globalCfg.Store
+}
+
+// ResolveTargets resolves agent targets with priority: flag > project > global
+func ResolveTargets(flagTargets []string, projectCfg *Config, globalCfg *GlobalConfig) []string {
+	if len(flagTargets) > 0 {
+		return flagTargets
+	}
+	if projectCfg != nil && len(projectCfg.Targets) > 0 {
+		return projectCfg.Targets
+	}
+	if globalCfg != nil {
+		return globalCfg.Targets
+	}
+	return nil
 }
 
 // ResolveRef resolves the registry ref with priority: flag > project > global > default
@@ -151,3 +319,18 @@ func ResolveRef(flagBranch, flagRef string, projectCfg *Config, globalCfg *Globa
 	// Priority 4: Default
 	return "main"
 }
+
+// ResolveSkillRef resolves the ref of one skill entry with priority:
+// flag > entry > project > global > default. Project and global refs only
+// apply to skills from the default registry.
+func ResolveSkillRef(entry SkillEntry, flagBranch, flagRef string, projectCfg *Config, globalCfg *GlobalConfig) string {
+	switch {
+	case flagRef != "" || flagBranch != "":
+		return ResolveRef(flagBranch, flagRef, nil, nil)
+	case entry.Ref != "":
+		return entry.Ref
+	case entry.Source != "":
+		return ResolveRef("", "", nil, nil)
+	}
+	return ResolveRef("", "", projectCfg, globalCfg)
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveKeepsDocument(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		edit   func(cfg *Config)
+		want   string
+	}{
+		{
+			name: "unchanged",
+			before: `# Skills for this repo
+targets: [claude, cursor] # agents we use
+skills:
+  - common/code-reviewer # always on
+`,
+			edit: func(cfg *Config) {},
+			want: `# Skills for this repo
+targets: [claude, cursor] # agents we use
+skills:
+  - common/code-reviewer # always on
+`,
+		},
+		{
+			name: "add skill",
+			before: `skills:
+  # reviewers
+  - common/code-reviewer # always on
+targets:
+  - claude
+`,
+			edit: func(cfg *Config) { cfg.Skills, _ = AddSkill(cfg.Skills, "go/testing") },
+			want: `skills:
+  # reviewers
+  - common/code-reviewer # always on
+  - go/testing
+targets:
+  - claude
+`,
+		},
+		{
+			name: "remove skill",
+			before: `skills:
+    - common/code-reviewer
+    - go/testing # flaky
+    - docs/writer # keep
+`,
+			edit: func(cfg *Config) { cfg.Skills, _ = RemoveSkill(cfg.Skills, "testing") },
+			want: `skills:
+    - common/code-reviewer
+    - docs/writer # keep
+`,
+		},
+		{
+			name:   "empty list",
+			before: "skills: [] # none yet\n",
+			edit:   func(cfg *Config) { cfg.Skills, _ = AddSkill(cfg.Skills, "go/testing") },
+			want:   "skills: # none yet\n    - go/testing\n",
+		},
+		{
+			name: "changed value",
+			before: `registry:
+  ref: v1.0.0 # pinned
+skills:
+  - common/code-reviewer
+`,
+			edit: func(cfg *Config) { cfg.Registry.Ref = "v1.1.0" },
+			want: `registry:
+  ref: v1.1.0 # pinned
+skills:
+  - common/code-reviewer
+`,
+		},
+		{
+			name: "object entry",
+			before: `# project skills
+skills:
+  - common/code-reviewer
+`,
+			edit: func(cfg *Config) { cfg.Skills[0].Ref = "main" },
+			want: `# project skills
+skills:
+  - name: common/code-reviewer
+    ref: main
+version: 2
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.before), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(cfg)
+			if err := Save(dir, cfg); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveNewFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := Save(dir, GetDefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, ConfigFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "skills:\n    - common/code-reviewer\n"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
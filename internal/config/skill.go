@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the config format written by this version. Version 1
+// files list skills as plain strings; version 2 also allows objects.
+const SchemaVersion = 2
+
+// SkillEntry is one item of the 'skills' list. In YAML it is either a plain
+// "name" or "stack/name" string, or an object with per-skill settings.
+type SkillEntry struct {
+	Name    string   `yaml:"name"`              // Skill name or stack/name
+	Source  string   `yaml:"source,omitempty"`  // Registry to fetch from, e.g. github.com/owner/repo
+	Ref     string   `yaml:"ref,omitempty"`     // Branch, tag or commit, overriding the registry ref
+	Version string   `yaml:"version,omitempty"` // Skill version the ref is expected to provide
+	Target  []string `yaml:"target,omitempty"`  // Agent formats for this skill, overriding 'targets'
+	Enabled *bool    `yaml:"enabled,omitempty"` // false keeps the entry but skips the skill
+	With    []string `yaml:"with,omitempty"`    // Optional components (skill subdirectories) to install
+}
+
+// skillEntryFields has SkillEntry's fields without its YAML methods
+type skillEntryFields SkillEntry
+
+// UnmarshalYAML accepts both the plain string and the object form
+func (e *SkillEntry) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*e = SkillEntry{Name: value.Value}
+		return nil
+	}
+
+	var fields skillEntryFields
+	if err := value.Decode(&fields); err != nil {
+		return err
+	}
+	if fields.Name == "" {
+		return fmt.Errorf("line %d: skill entry needs a name", value.Line)
+	}
+	*e = SkillEntry(fields)
+	return nil
+}
+
+// MarshalYAML writes entries without settings as plain strings
+func (e SkillEntry) MarshalYAML() (interface{}, error) {
+	if e.Simple() {
+		return e.Name, nil
+	}
+	return skillEntryFields(e), nil
+}
+
+// Simple reports whether the entry has no settings beyond its name
+func (e SkillEntry) Simple() bool {
+	return e.Source == "" && e.Ref == "" && e.Version == "" &&
+		len(e.Target) == 0 && len(e.With) == 0 && e.IsEnabled()
+}
+
+// IsEnabled reports whether the skill should be installed
+func (e SkillEntry) IsEnabled() bool {
+	return e.Enabled == nil || *e.Enabled
+}
+
+func (e SkillEntry) String() string {
+	return e.Name
+}
+
+// Skills turns plain names into entries
+func Skills(names ...string) []SkillEntry {
+	entries := make([]SkillEntry, len(names))
+	for i, name := range names {
+		entries[i] = SkillEntry{Name: name}
+	}
+	return entries
+}
+
+// AddSkill appends a skill (name or stack/name) unless a skill with the same
+// name is already listed, in which case a disabled entry is enabled again
+func AddSkill(skills []SkillEntry, skill string) ([]SkillEntry, bool) {
+	for i, s := range skills {
+		if path.Base(s.Name) == path.Base(skill) {
+			if s.IsEnabled() {
+				return skills, false
+			}
+			skills[i].Enabled = nil
+			return skills, true
+		}
+	}
+	return append(skills, SkillEntry{Name: skill}), true
+}
+
+// RemoveSkill drops every entry for a skill name, with or without its stack
+func RemoveSkill(skills []SkillEntry, name string) ([]SkillEntry, bool) {
+	kept := skills[:0:0]
+	for _, s := range skills {
+		if path.Base(s.Name) != path.Base(name) {
+			kept = append(kept, s)
+		}
+	}
+	return kept, len(kept) != len(skills)
+}
+
+// AddStack appends a stack unless it is already listed
+func AddStack(stacks []string, stack string) ([]string, bool) {
+	for _, s := range stacks {
+		if s == stack {
+			return stacks, false
+		}
+	}
+	return append(stacks, stack), true
+}
+
+// checkVersion rejects config files written by a newer CLI
+func checkVersion(version int, file string) error {
+	if version > SchemaVersion {
+		return fmt.Errorf("%s uses config version %d, newer than this CLI supports (%d): run 'vibe-skills self-update'", file, version, SchemaVersion)
+	}
+	return nil
+}
+
+// minimalVersion returns the schema version a skills list needs: 0 (omitted)
+// while every entry is a plain string, so v1 readers still understand it
+func minimalVersion(skills []SkillEntry) int {
+	for _, s := range skills {
+		if !s.Simple() {
+			return SchemaVersion
+		}
+	}
+	return 0
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestSkillEntryRoundTrip(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		name  string
+		yaml  string
+		entry SkillEntry
+		want  string // Minimal form written back; yaml when empty
+	}{
+		{
+			name:  "string",
+			yaml:  "go/testing\n",
+			entry: SkillEntry{Name: "go/testing"},
+		},
+		{
+			name:  "object with name only",
+			yaml:  "name: go/testing\n",
+			entry: SkillEntry{Name: "go/testing"},
+			want:  "go/testing\n",
+		},
+		{
+			name:  "enabled object",
+			yaml:  "name: go/testing\nenabled: true\n",
+			entry: SkillEntry{Name: "go/testing", Enabled: &enabled},
+			want:  "go/testing\n",
+		},
+		{
+			name:  "pinned",
+			yaml:  "name: go/testing\nref: v1.2.0\nversion: 1.2.0\n",
+			entry: SkillEntry{Name: "go/testing", Ref: "v1.2.0", Version: "1.2.0"},
+		},
+		{
+			name:  "disabled",
+			yaml:  "name: go/testing\nenabled: false\n",
+			entry: SkillEntry{Name: "go/testing", Enabled: &disabled},
+		},
+		{
+			name:  "all settings",
+			yaml:  "name: testing\nsource: github.com/acme/skills\nref: main\ntarget:\n    - cursor\nwith:\n    - scripts\n",
+			entry: SkillEntry{Name: "testing", Source: "github.com/acme/skills", Ref: "main", Target: []string{"cursor"}, With: []string{"scripts"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got SkillEntry
+			if err := yaml.Unmarshal([]byte(tt.yaml), &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.entry) {
+				t.Errorf("Unmarshal = %+v, want %+v", got, tt.entry)
+			}
+
+			out, err := yaml.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tt.want
+			if want == "" {
+				want = tt.yaml
+			}
+			if string(out) != want {
+				t.Errorf("Marshal = %q, want %q", out, want)
+			}
+		})
+	}
+}
+
+func TestSkillEntryNeedsName(t *testing.T) {
+	var e SkillEntry
+	if err := yaml.Unmarshal([]byte("ref: main\n"), &e); err == nil {
+		t.Errorf("want an error for an entry without a name, got %+v", e)
+	}
+}
+
+func TestSaveMinimalVersion(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		want   string
+	}{
+		{
+			name:   "v1 strings stay unversioned",
+			before: "skills:\n    - go/testing\n",
+			want:   "skills:\n    - go/testing\n",
+		},
+		{
+			name:   "v2 strings drop the version",
+			before: "version: 2\nskills:\n    - go/testing\n",
+			want:   "skills:\n    - go/testing\n",
+		},
+		{
+			name:   "plain objects become strings",
+			before: "version: 2\nskills:\n    - name: go/testing\n",
+			want:   "skills:\n    - go/testing\n",
+		},
+		{
+			name:   "settings keep the version",
+			before: "version: 2\nskills:\n    - go/testing\n    - name: docs/writer\n      ref: main\n",
+			want:   "version: 2\nskills:\n    - go/testing\n    - name: docs/writer\n      ref: main\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.before), 0644); err != nil {
+				t.Fatal(err)
+			}
+			cfg, err := Load(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Version != SchemaVersion {
+				t.Errorf("Load: Version = %d, want %d", cfg.Version, SchemaVersion)
+			}
+			if err := Save(dir, cfg); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadNewerVersion(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte("version: 99\nskills: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil {
+		t.Error("want an error for a config newer than SchemaVersion")
+	}
+}
@@ -0,0 +1,137 @@
+package detect
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+// maxContentSize bounds how much of a file is read to check Contains
+const maxContentSize = 1 << 20
+
+// skipDirs are dependency and build directories that say nothing about the
+// project's own stack. Hidden directories are skipped as well.
+var skipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"bin":          true,
+	"obj":          true,
+	"build":        true,
+	"dist":         true,
+	"target":       true,
+}
+
+// Match is a rule that fired, with the first file that triggered it
+type Match struct {
+	Rule registry.DetectRule
+	File string // Slash-separated, relative to the scanned directory
+}
+
+// Scan walks dir and returns the rules that have a matching file, in rule order
+func Scan(dir string, rules []registry.DetectRule) ([]Match, error) {
+	found := make(map[int]string)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Detection is best-effort: skip what cannot be read, unless it
+			// is the project directory itself
+			switch {
+			case p == dir:
+				return err
+			case d != nil && d.IsDir():
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			if p != dir && (strings.HasPrefix(d.Name(), ".") || skipDirs[d.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		for i, rule := range rules {
+			if _, ok := found[i]; ok || !matchesAny(rule.Files, rel) {
+				continue
+			}
+			if rule.Contains != "" && !fileContains(p, rule.Contains) {
+				continue
+			}
+			found[i] = rel
+		}
+		if len(found) == len(rules) {
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Match
+	for i, rule := range rules {
+		if file, ok := found[i]; ok {
+			matches = append(matches, Match{Rule: rule, File: file})
+		}
+	}
+	return matches, nil
+}
+
+// Skills lists the skills of the matched rules, without duplicates
+func Skills(matches []Match) []string {
+	var skills []string
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		for _, s := range m.Rule.Skills {
+			if !seen[s] {
+				seen[s] = true
+				skills = append(skills, s)
+			}
+		}
+	}
+	return skills
+}
+
+// matchesAny reports whether a path matches one of the globs. Globs without
+// a '/' match the file name; others match the trailing path segments, so
+// "Migrations/*.cs" finds src/Data/Migrations/Init.cs.
+func matchesAny(globs []string, rel string) bool {
+	segments := strings.Split(rel, "/")
+	for _, glob := range globs {
+		n := strings.Count(glob, "/") + 1
+		if n > len(segments) {
+			continue
+		}
+		tail := strings.Join(segments[len(segments)-n:], "/")
+		if ok, _ := path.Match(glob, tail); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// fileContains reports whether the start of a file contains text, ignoring case
+func fileContains(p, text string) bool {
+	f, err := os.Open(p)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxContentSize))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(bytes.ToLower(data), bytes.ToLower([]byte(text)))
+}
@@ -0,0 +1,357 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change
+const DefaultContext = 3
+
+// OpKind is the type of a line edit
+type OpKind int
+
+const (
+	Equal OpKind = iota
+	Insert
+	Delete
+)
+
+// Op is one line of an edit script
+type Op struct {
+	Kind OpKind
+	Line string
+	A, B int // 0-based line numbers in a and b (the side the line is not on is the insertion point)
+}
+
+// SplitLines splits text into lines, keeping line endings so output
+// reproduces the input exactly
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// maxEditDistance caps the number of line edits Lines searches for. Texts
+// that differ by more have little left in common, so replacing the differing
+// region wholesale says as much as a minimal script and costs far less to
+// find: the search takes time proportional to the edits times the lines.
+const maxEditDistance = 2000
+
+// Lines computes the shortest edit script from a to b (Myers' algorithm, in
+// linear space). Past maxEditDistance edits it stops looking for a minimal
+// script and deletes and reinserts everything between the common prefix and
+// suffix instead.
+func Lines(a, b []string) []Op {
+	var ops []Op
+	diffLines(&ops, a, b, 0, 0, maxEditDistance)
+	return ops
+}
+
+// diffLines appends the edit script from a to b, which start at lines x0 and
+// y0 of the full texts, splitting the problem at the middle snake of a
+// shortest path until what is left is only insertions or only deletions
+func diffLines(ops *[]Op, a, b []string, x0, y0, limit int) {
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		*ops = append(*ops, Op{Kind: Equal, Line: a[pre], A: x0 + pre, B: y0 + pre})
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+
+	ma, mb := a[pre:len(a)-suf], b[pre:len(b)-suf]
+	mx, my := x0+pre, y0+pre
+	if len(ma) == 0 || len(mb) == 0 {
+		replaceLines(ops, ma, mb, mx, my)
+	} else if x, y, u, v, ok := middleSnake(ma, mb, limit); !ok {
+		replaceLines(ops, ma, mb, mx, my)
+	} else {
+		// Both halves need fewer edits than the whole, so no limit applies
+		diffLines(ops, ma[:x], mb[:y], mx, my, x+y)
+		for i := x; i < u; i++ {
+			*ops = append(*ops, Op{Kind: Equal, Line: ma[i], A: mx + i, B: my + y + i - x})
+		}
+		diffLines(ops, ma[u:], mb[v:], mx+u, my+v, len(ma)-u+len(mb)-v)
+	}
+
+	for i := len(a) - suf; i < len(a); i++ {
+		*ops = append(*ops, Op{Kind: Equal, Line: a[i], A: x0 + i, B: y0 + len(b) - len(a) + i})
+	}
+}
+
+// replaceLines appends the deletion of all of a followed by the insertion of
+// all of b
+func replaceLines(ops *[]Op, a, b []string, x0, y0 int) {
+	for i, line := range a {
+		*ops = append(*ops, Op{Kind: Delete, Line: line, A: x0 + i, B: y0})
+	}
+	for j, line := range b {
+		*ops = append(*ops, Op{Kind: Insert, Line: line, A: x0 + len(a), B: y0 + j})
+	}
+}
+
+// middleSnake finds the middle snake of a shortest edit path from a to b:
+// the run of equal lines, from (x, y) to (u, v), that a forward search from
+// the start and a backward search from the end meet on. Only the furthest
+// point on each live diagonal is kept, so memory is linear in the input.
+// ok is false when the path needs more than limit edits.
+func middleSnake(a, b []string, limit int) (x, y, u, v int, ok bool) {
+	n, m := len(a), len(b)
+	max := (n + m + 1) / 2
+	delta := n - m
+	odd := delta%2 != 0
+
+	// vf[off+k] is the furthest x reached forward on diagonal k = x-y;
+	// vb[off+k] the same for the backward search, counted from the end
+	off := max + 1
+	vf := make([]int, 2*max+3)
+	vb := make([]int, 2*max+3)
+
+	for d := 0; d <= max; d++ {
+		if 2*d-1 > limit {
+			return 0, 0, 0, 0, false
+		}
+
+		for k := -d; k <= d; k += 2 {
+			if k == -d || (k != d && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y = x - k
+			u, v = x, y
+			for u < n && v < m && a[u] == b[v] {
+				u++
+				v++
+			}
+			vf[off+k] = u
+			if kb := delta - k; odd && kb >= -(d-1) && kb <= d-1 && u+vb[off+kb] >= n {
+				return x, y, u, v, true
+			}
+		}
+
+		if 2*d > limit {
+			return 0, 0, 0, 0, false
+		}
+
+		for k := -d; k <= d; k += 2 {
+			var bx int
+			if k == -d || (k != d && vb[off+k-1] < vb[off+k+1]) {
+				bx = vb[off+k+1]
+			} else {
+				bx = vb[off+k-1] + 1
+			}
+			by := bx - k
+			ex, ey := bx, by
+			for ex < n && ey < m && a[n-1-ex] == b[m-1-ey] {
+				ex++
+				ey++
+			}
+			vb[off+k] = ex
+			if kf := delta - k; !odd && kf >= -d && kf <= d && ex+vf[off+kf] >= n {
+				return n - ex, m - ey, n - bx, m - by, true
+			}
+		}
+	}
+	return 0, 0, 0, 0, false
+}
+
+// Stats counts inserted and deleted lines
+func Stats(ops []Op) (added, removed int) {
+	for _, op := range ops {
+		switch op.Kind {
+		case Insert:
+			added++
+		case Delete:
+			removed++
+		}
+	}
+	return
+}
+
+// IsBinary reports whether content is not line-oriented text: it contains a
+// NUL byte or is not valid UTF-8
+func IsBinary(content []byte) bool {
+	return bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content)
+}
+
+// Unified renders a unified diff between two texts. Returns "" when equal.
+// Binary content is not diffed line by line; like git, the result only says
+// that the files differ.
+func Unified(fromName, toName string, a, b []byte, context int) string {
+	if IsBinary(a) || IsBinary(b) {
+		if bytes.Equal(a, b) {
+			return ""
+		}
+		return fmt.Sprintf("Binary files %s and %s differ\n", fromName, toName)
+	}
+	ops := Lines(SplitLines(string(a)), SplitLines(string(b)))
+	hunks := Hunks(ops, context)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+	for _, h := range hunks {
+		out.WriteString(h.String())
+	}
+	return out.String()
+}
+
+// Hunk is a group of changes with surrounding context
+type Hunk struct {
+	AStart, ALen int // 1-based start line and length in a
+	BStart, BLen int
+	Ops          []Op
+}
+
+// String renders the hunk header and lines
+func (h Hunk) String() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(h.AStart, h.ALen), hunkRange(h.BStart, h.BLen))
+	for _, op := range h.Ops {
+		prefix := " "
+		switch op.Kind {
+		case Insert:
+			prefix = "+"
+		case Delete:
+			prefix = "-"
+		}
+		out.WriteString(prefix + op.Line)
+		if !strings.HasSuffix(op.Line, "\n") {
+			out.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+	return out.String()
+}
+
+func hunkRange(start, length int) string {
+	if length == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	if length == 0 {
+		// Empty ranges point at the line before the change
+		start--
+	}
+	return fmt.Sprintf("%d,%d", start, length)
+}
+
+// Hunks groups an edit script into hunks with the given lines of context
+func Hunks(ops []Op, context int) []Hunk {
+	var hunks []Hunk
+	i := 0
+	for i < len(ops) {
+		// Find the next change
+		for i < len(ops) && ops[i].Kind == Equal {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		// Extend while changes are within 2*context of each other
+		end := i
+		for end < len(ops) {
+			if ops[end].Kind != Equal {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].Kind == Equal {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				end += min(context, run-end)
+				break
+			}
+			end = run
+		}
+
+		h := Hunk{Ops: ops[start:end]}
+		h.AStart, h.BStart = ops[start].A+1, ops[start].B+1
+		for _, op := range h.Ops {
+			if op.Kind != Insert {
+				h.ALen++
+			}
+			if op.Kind != Delete {
+				h.BLen++
+			}
+		}
+		hunks = append(hunks, h)
+		i = end
+	}
+	return hunks
+}
+
+// File change kinds reported by Compare
+const (
+	FileAdded   = "added"
+	FileRemoved = "removed"
+	FileChanged = "changed"
+)
+
+// FileChange summarises how one file differs between two versions of a tree
+type FileChange struct {
+	Path    string `json:"path"`
+	Status  string `json:"status"`
+	Added   int    `json:"added"`
+	Removed int    `json:"removed"`
+	Binary  bool   `json:"binary,omitempty"` // Binary content, not counted in lines
+}
+
+// Compare lists the files that differ between two trees keyed by path,
+// sorted by path. Line counts are left at zero for binary files.
+func Compare(a, b map[string][]byte) []FileChange {
+	paths := make(map[string]bool)
+	for p := range a {
+		paths[p] = true
+	}
+	for p := range b {
+		paths[p] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	var changes []FileChange
+	for _, p := range sorted {
+		from, inA := a[p]
+		to, inB := b[p]
+		if inA && inB && string(from) == string(to) {
+			continue
+		}
+
+		c := FileChange{Path: p, Status: FileChanged}
+		switch {
+		case !inA:
+			c.Status = FileAdded
+		case !inB:
+			c.Status = FileRemoved
+		}
+		if IsBinary(from) || IsBinary(to) {
+			c.Binary = true
+		} else {
+			c.Added, c.Removed = Stats(Lines(SplitLines(string(from)), SplitLines(string(to))))
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
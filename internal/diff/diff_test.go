@@ -0,0 +1,151 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// script renders ops compactly: " x" for equal, "-x" for deleted and "+x"
+// for inserted lines
+func script(ops []Op) string {
+	var b strings.Builder
+	for _, op := range ops {
+		b.WriteString(map[OpKind]string{Equal: " ", Delete: "-", Insert: "+"}[op.Kind])
+		b.WriteString(strings.TrimSuffix(op.Line, "\n"))
+	}
+	return b.String()
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		want  string // Expected script, when only one is minimal
+		edits int
+	}{
+		{name: "empty", a: "", b: "", want: ""},
+		{name: "equal", a: "abc", b: "abc", want: " a b c"},
+		{name: "insert all", a: "", b: "ab", want: "+a+b", edits: 2},
+		{name: "delete all", a: "ab", b: "", want: "-a-b", edits: 2},
+		{name: "insert in middle", a: "ac", b: "abc", want: " a+b c", edits: 1},
+		{name: "replace", a: "abc", b: "axc", want: " a-b+x c", edits: 2},
+		{name: "moved line", a: "abcd", b: "bcda", want: "-a b c d+a", edits: 2},
+		{name: "myers paper", a: "abcabba", b: "cbabac", edits: 5},
+		{name: "interleaved", a: "axbxcxdx", b: "xaxbxcxd", edits: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := strings.Split(tt.a, ""), strings.Split(tt.b, "")
+			ops := Lines(a, b)
+			if got := script(ops); tt.want != "" && got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if added, removed := Stats(ops); added+removed != tt.edits {
+				t.Errorf("%d edits (%q), want %d", added+removed, script(ops), tt.edits)
+			}
+
+			// Line numbers must walk both texts in order
+			x, y := 0, 0
+			for _, op := range ops {
+				if op.A != x || op.B != y {
+					t.Fatalf("op %+v at a=%d b=%d", op, x, y)
+				}
+				if op.Kind != Insert {
+					x++
+				}
+				if op.Kind != Delete {
+					y++
+				}
+			}
+			if x != len(a) || y != len(b) {
+				t.Errorf("script covers a=%d b=%d, want %d and %d", x, y, len(a), len(b))
+			}
+		})
+	}
+}
+
+func TestLinesEditLimit(t *testing.T) {
+	// Every other line is shared, so a minimal script changes only the
+	// others, but it takes 2*maxEditDistance edits: too many to look for
+	var a, b []string
+	for n := 0; n < maxEditDistance; n++ {
+		a = append(a, fmt.Sprintf("same %d\n", n), fmt.Sprintf("old %d\n", n))
+		b = append(b, fmt.Sprintf("same %d\n", n), fmt.Sprintf("new %d\n", n))
+	}
+
+	ops := Lines(a, b)
+	// Only the common first line survives the whole-region replacement
+	want := 2*maxEditDistance - 1
+	if added, removed := Stats(ops); added != want || removed != want {
+		t.Fatalf("Stats = +%d -%d, want +%d -%d", added, removed, want, want)
+	}
+	if ops[0].Kind != Equal || ops[1].Kind != Delete || ops[len(ops)-1].Kind != Insert {
+		t.Errorf("want an equal line, deletions, then insertions; got %q...", script(ops[:3]))
+	}
+
+	// Under the limit the same shape diffs line by line
+	ops = Lines(a[:200], b[:200])
+	if added, removed := Stats(ops); added != 100 || removed != 100 {
+		t.Errorf("Stats = +%d -%d, want +100 -100", added, removed)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	from := map[string][]byte{
+		"SKILL.md":     []byte("a\nb\nc\n"),
+		"same.md":      []byte("x\n"),
+		"gone.md":      []byte("1\n2\n"),
+		"logo.png":     {0x89, 'P', 'N', 'G', 0, 1},
+		"latin1.txt":   {'c', 'a', 'f', 0xe9, '\n'},
+		"to-binary.md": []byte("text\n"),
+	}
+	to := map[string][]byte{
+		"SKILL.md":     []byte("a\nB\nc\nd\n"),
+		"same.md":      []byte("x\n"),
+		"new.md":       []byte("n\n"),
+		"logo.png":     {0x89, 'P', 'N', 'G', 0, 2},
+		"latin1.txt":   {'c', 'a', 'f', 0xe8, '\n'},
+		"to-binary.md": {0, 0},
+	}
+
+	want := []FileChange{
+		{Path: "SKILL.md", Status: FileChanged, Added: 2, Removed: 1},
+		{Path: "gone.md", Status: FileRemoved, Removed: 2},
+		{Path: "latin1.txt", Status: FileChanged, Binary: true},
+		{Path: "logo.png", Status: FileChanged, Binary: true},
+		{Path: "new.md", Status: FileAdded, Added: 1},
+		{Path: "to-binary.md", Status: FileChanged, Binary: true},
+	}
+	got := Compare(from, to)
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for n := range want {
+		if got[n] != want[n] {
+			t.Errorf("change %d = %+v, want %+v", n, got[n], want[n])
+		}
+	}
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{name: "equal", a: "x\n", b: "x\n", want: ""},
+		{name: "text", a: "x\ny\n", b: "x\nz\n", want: "--- a\n+++ b\n@@ -1,2 +1,2 @@\n x\n-y\n+z\n"},
+		{name: "binary", a: "x\x00", b: "y\x00", want: "Binary files a and b differ\n"},
+		{name: "equal binary", a: "x\x00", b: "x\x00", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Unified("a", "b", []byte(tt.a), []byte(tt.b), DefaultContext); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package diff
+
+import "strings"
+
+// Conflict resolution for Merge3
+const (
+	ResolveMarkers  = "merge"  // Leave conflict markers in the output
+	ResolveOurs     = "ours"   // Take the local side of conflicting hunks
+	ResolveTheirs   = "theirs" // Take the upstream side of conflicting hunks
+	markerLocal     = "<<<<<<< local\n"
+	markerBase      = "||||||| base\n"
+	markerSeparator = "=======\n"
+	markerUpstream  = ">>>>>>> upstream\n"
+)
+
+// MergeResult is the outcome of a three-way merge
+type MergeResult struct {
+	Content   []byte
+	Conflicts int // Number of conflicting hunks (marked or resolved by strategy)
+}
+
+// Merge3 merges the changes from base to local and from base to upstream.
+// Non-overlapping changes from both sides are combined; overlapping changes
+// that differ are conflicts, handled according to resolve.
+func Merge3(base, local, upstream []byte, resolve string) MergeResult {
+	b := SplitLines(string(base))
+	l := SplitLines(string(local))
+	u := SplitLines(string(upstream))
+
+	lm := matches(Lines(b, l), len(b))
+	um := matches(Lines(b, u), len(b))
+
+	var out strings.Builder
+	conflicts := 0
+	i, j, k := 0, 0, 0
+
+	for i < len(b) || j < len(l) || k < len(u) {
+		// Stable line: unchanged on both sides
+		if i < len(b) && lm[i] == j && um[i] == k {
+			out.WriteString(b[i])
+			i, j, k = i+1, j+1, k+1
+			continue
+		}
+
+		// Find the next base line both sides kept, in order
+		ni := i
+		for ni < len(b) && !(lm[ni] >= j && um[ni] >= k) {
+			ni++
+		}
+		nj, nk := len(l), len(u)
+		if ni < len(b) {
+			nj, nk = lm[ni], um[ni]
+		}
+
+		bc, lc, uc := b[i:ni], l[j:nj], u[k:nk]
+		switch {
+		case equalLines(lc, bc):
+			writeLines(&out, uc)
+		case equalLines(uc, bc), equalLines(lc, uc):
+			writeLines(&out, lc)
+		default:
+			conflicts++
+			switch resolve {
+			case ResolveOurs:
+				writeLines(&out, lc)
+			case ResolveTheirs:
+				writeLines(&out, uc)
+			default:
+				out.WriteString(markerLocal)
+				writeSide(&out, lc)
+				out.WriteString(markerBase)
+				writeSide(&out, bc)
+				out.WriteString(markerSeparator)
+				writeSide(&out, uc)
+				out.WriteString(markerUpstream)
+			}
+		}
+		i, j, k = ni, nj, nk
+	}
+
+	return MergeResult{Content: []byte(out.String()), Conflicts: conflicts}
+}
+
+// HasConflictMarkers reports whether content still contains merge markers
+func HasConflictMarkers(content []byte) bool {
+	s := string(content)
+	return strings.Contains(s, markerLocal) && strings.Contains(s, markerUpstream)
+}
+
+// matches maps each line of a to its line in b, or -1 if it was changed
+func matches(ops []Op, n int) []int {
+	m := make([]int, n)
+	for i := range m {
+		m[i] = -1
+	}
+	for _, op := range ops {
+		if op.Kind == Equal {
+			m[op.A] = op.B
+		}
+	}
+	return m
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(out *strings.Builder, lines []string) {
+	for _, line := range lines {
+		out.WriteString(line)
+	}
+}
+
+// writeSide writes one side of a conflict; a final line without a newline
+// gets one so the following marker starts on its own line
+func writeSide(out *strings.Builder, lines []string) {
+	writeLines(out, lines)
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		out.WriteString("\n")
+	}
+}
@@ -0,0 +1,100 @@
+package diff
+
+import "testing"
+
+func TestMerge3(t *testing.T) {
+	const base = "a\nb\nc\nd\ne\n"
+
+	tests := []struct {
+		name      string
+		local     string
+		upstream  string
+		resolve   string
+		want      string
+		conflicts int
+	}{
+		{
+			name:     "unchanged",
+			local:    base,
+			upstream: base,
+			want:     base,
+		},
+		{
+			name:     "local only",
+			local:    "a\nB\nc\nd\ne\n",
+			upstream: base,
+			want:     "a\nB\nc\nd\ne\n",
+		},
+		{
+			name:     "upstream only",
+			local:    base,
+			upstream: "a\nb\nc\nD\ne\nf\n",
+			want:     "a\nb\nc\nD\ne\nf\n",
+		},
+		{
+			name:     "non-overlapping changes",
+			local:    "a\nB\nc\nd\ne\n",
+			upstream: "a\nb\nc\nD\ne\n",
+			want:     "a\nB\nc\nD\ne\n",
+		},
+		{
+			name:     "same change on both sides",
+			local:    "a\nX\nc\nd\ne\n",
+			upstream: "a\nX\nc\nd\ne\n",
+			want:     "a\nX\nc\nd\ne\n",
+		},
+		{
+			name:     "local deletion and upstream insertion",
+			local:    "a\nc\nd\ne\n",
+			upstream: "a\nb\nc\nd\ne\nf\n",
+			want:     "a\nc\nd\ne\nf\n",
+		},
+		{
+			name:      "conflict with markers",
+			local:     "a\nL\nc\nd\ne\n",
+			upstream:  "a\nU\nc\nd\ne\n",
+			resolve:   ResolveMarkers,
+			want:      "a\n<<<<<<< local\nL\n||||||| base\nb\n=======\nU\n>>>>>>> upstream\nc\nd\ne\n",
+			conflicts: 1,
+		},
+		{
+			name:      "conflict resolved as ours",
+			local:     "a\nL\nc\nd\ne\n",
+			upstream:  "a\nU\nc\nd\nE\n",
+			resolve:   ResolveOurs,
+			want:      "a\nL\nc\nd\nE\n",
+			conflicts: 1,
+		},
+		{
+			name:      "conflict resolved as theirs",
+			local:     "a\nL\nc\nd\ne\n",
+			upstream:  "a\nU\nc\nd\ne\n",
+			resolve:   ResolveTheirs,
+			want:      "a\nU\nc\nd\ne\n",
+			conflicts: 1,
+		},
+		{
+			name:      "conflict on a last line without newline",
+			local:     "a\nb\nc\nd\nL",
+			upstream:  "a\nb\nc\nd\nU",
+			resolve:   ResolveMarkers,
+			want:      "a\nb\nc\nd\n<<<<<<< local\nL\n||||||| base\ne\n=======\nU\n>>>>>>> upstream\n",
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Merge3([]byte(base), []byte(tt.local), []byte(tt.upstream), tt.resolve)
+			if string(got.Content) != tt.want {
+				t.Errorf("content = %q, want %q", got.Content, tt.want)
+			}
+			if got.Conflicts != tt.conflicts {
+				t.Errorf("conflicts = %d, want %d", got.Conflicts, tt.conflicts)
+			}
+			if markers := HasConflictMarkers(got.Content); markers != (tt.conflicts > 0 && tt.resolve == ResolveMarkers) {
+				t.Errorf("HasConflictMarkers = %v", markers)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DevNull is the file name a patch uses for a created or deleted file
+const DevNull = "/dev/null"
+
+// FilePatch is the set of hunks a unified diff applies to one file
+type FilePatch struct {
+	OldName string
+	NewName string
+	Hunks   []Hunk
+}
+
+// HunkError reports a hunk whose context no longer matches the file
+type HunkError struct {
+	N    int // 1-based hunk number
+	Hunk Hunk
+}
+
+func (e *HunkError) Error() string {
+	return fmt.Sprintf("hunk #%d does not apply:\n%s", e.N, strings.TrimSuffix(e.Hunk.String(), "\n"))
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// ParsePatch parses a unified diff into per-file patches. Git headers and
+// other lines outside file sections are ignored.
+func ParsePatch(data []byte) ([]FilePatch, error) {
+	lines := SplitLines(string(data))
+	var patches []FilePatch
+
+	for n := 0; n < len(lines); n++ {
+		if !strings.HasPrefix(lines[n], "--- ") || n+1 >= len(lines) || !strings.HasPrefix(lines[n+1], "+++ ") {
+			continue
+		}
+		fp := FilePatch{
+			OldName: patchName(lines[n][4:]),
+			NewName: patchName(lines[n+1][4:]),
+		}
+		n += 2
+
+		for n < len(lines) && strings.HasPrefix(lines[n], "@@") {
+			h, next, err := parseHunk(lines, n)
+			if err != nil {
+				return nil, err
+			}
+			fp.Hunks = append(fp.Hunks, h)
+			n = next
+		}
+		if len(fp.Hunks) == 0 {
+			return nil, fmt.Errorf("no hunks for %s", fp.NewName)
+		}
+		patches = append(patches, fp)
+		n--
+	}
+
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("no file changes found")
+	}
+	return patches, nil
+}
+
+// parseHunk parses the hunk starting at lines[n] and returns the index of
+// the line after it
+func parseHunk(lines []string, n int) (Hunk, int, error) {
+	m := hunkHeader.FindStringSubmatch(lines[n])
+	if m == nil {
+		return Hunk{}, 0, fmt.Errorf("line %d: malformed hunk header: %s", n+1, strings.TrimSpace(lines[n]))
+	}
+	h := Hunk{
+		AStart: atoi(m[1], 0),
+		ALen:   atoi(m[2], 1),
+		BStart: atoi(m[3], 0),
+		BLen:   atoi(m[4], 1),
+	}
+	// Empty ranges name the line before the change
+	if h.ALen == 0 {
+		h.AStart++
+	}
+	if h.BLen == 0 {
+		h.BStart++
+	}
+
+	oldLeft, newLeft := h.ALen, h.BLen
+	n++
+	for n < len(lines) && (oldLeft > 0 || newLeft > 0) {
+		line := lines[n]
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		var op Op
+		switch line[0] {
+		case ' ':
+			op.Kind = Equal
+			oldLeft--
+			newLeft--
+		case '-':
+			op.Kind = Delete
+			oldLeft--
+		case '+':
+			op.Kind = Insert
+			newLeft--
+		case '\n':
+			// Some tools drop the space of empty context lines
+			op.Kind = Equal
+			line = " \n"
+			oldLeft--
+			newLeft--
+		default:
+			return Hunk{}, 0, fmt.Errorf("line %d: unexpected line in hunk: %s", n+1, strings.TrimSpace(line))
+		}
+		op.Line = line[1:]
+		h.Ops = append(h.Ops, op)
+		n++
+
+		if n < len(lines) && strings.HasPrefix(lines[n], `\`) {
+			last := &h.Ops[len(h.Ops)-1]
+			last.Line = strings.TrimSuffix(last.Line, "\n")
+			n++
+		}
+	}
+	if oldLeft != 0 || newLeft != 0 {
+		return Hunk{}, 0, fmt.Errorf("line %d: hunk is shorter than its header", n)
+	}
+	return h, n, nil
+}
+
+// Apply applies hunks to content. Hunks may have moved since the patch was
+// made, but their context must still match exactly.
+func Apply(content []byte, hunks []Hunk) ([]byte, error) {
+	lines := SplitLines(string(content))
+	var out strings.Builder
+	pos, offset := 0, 0
+
+	for n, h := range hunks {
+		var old []string
+		for _, op := range h.Ops {
+			if op.Kind != Insert {
+				old = append(old, op.Line)
+			}
+		}
+
+		at := findLines(lines, old, h.AStart-1+offset, pos)
+		if at < 0 {
+			return nil, &HunkError{N: n + 1, Hunk: h}
+		}
+
+		writeLines(&out, lines[pos:at])
+		for _, op := range h.Ops {
+			if op.Kind != Delete {
+				out.WriteString(op.Line)
+			}
+		}
+		pos = at + len(old)
+		offset = at - (h.AStart - 1)
+	}
+	writeLines(&out, lines[pos:])
+	return []byte(out.String()), nil
+}
+
+// findLines returns the index of want in lines closest to hint and not
+// before from, or -1
+func findLines(lines, want []string, hint, from int) int {
+	for d := 0; ; d++ {
+		before, after := hint-d, hint+d
+		if before < from && after+len(want) > len(lines) {
+			return -1
+		}
+		if before >= from && before+len(want) <= len(lines) && equalLines(lines[before:before+len(want)], want) {
+			return before
+		}
+		if d > 0 && after >= from && after+len(want) <= len(lines) && equalLines(lines[after:after+len(want)], want) {
+			return after
+		}
+	}
+}
+
+// patchName strips the timestamp and a/ b/ prefixes from a file header name
+func patchName(s string) string {
+	s = strings.TrimRight(s, "\r\n")
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i]
+	}
+	if s == DevNull {
+		return s
+	}
+	for _, prefix := range []string{"a/", "b/"} {
+		if strings.HasPrefix(s, prefix) {
+			return s[len(prefix):]
+		}
+	}
+	return s
+}
+
+func atoi(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,142 @@
+package diff
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	const patch = `--- a/SKILL.md
++++ b/SKILL.md
+@@ -2,3 +2,3 @@
+ two
+-three
++THREE
+ four
+@@ -8,2 +8,3 @@
+ eight
+ nine
++nine and a half
+`
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr int // 1-based number of the rejected hunk, 0 when it applies
+	}{
+		{
+			name:    "at the recorded lines",
+			content: "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n",
+			want:    "one\ntwo\nTHREE\nfour\nfive\nsix\nseven\neight\nnine\nnine and a half\nten\n",
+		},
+		{
+			name:    "shifted down",
+			content: "zero\nhalf\none\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n",
+			want:    "zero\nhalf\none\ntwo\nTHREE\nfour\nfive\nsix\nseven\neight\nnine\nnine and a half\nten\n",
+		},
+		{
+			name:    "shifted up",
+			content: "two\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n",
+			want:    "two\nTHREE\nfour\nfive\nsix\nseven\neight\nnine\nnine and a half\nten\n",
+		},
+		{
+			name:    "second hunk follows the offset of the first",
+			content: "one\ntwo\nthree\nfour\nfive\nsix\nseven\nextra\neight\nnine\nten\n",
+			want:    "one\ntwo\nTHREE\nfour\nfive\nsix\nseven\nextra\neight\nnine\nnine and a half\nten\n",
+		},
+		{
+			name:    "first hunk context changed",
+			content: "one\ntwo\n3\nfour\nfive\nsix\nseven\neight\nnine\nten\n",
+			wantErr: 1,
+		},
+		{
+			name:    "second hunk context gone",
+			content: "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nten\n",
+			wantErr: 2,
+		},
+		{
+			name:    "context only before the first hunk",
+			content: "eight\nnine\none\ntwo\nthree\nfour\n",
+			wantErr: 2,
+		},
+	}
+
+	patches, err := ParsePatch([]byte(patch))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patches) != 1 || patches[0].NewName != "SKILL.md" || len(patches[0].Hunks) != 2 {
+		t.Fatalf("ParsePatch = %+v", patches)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Apply([]byte(tt.content), patches[0].Hunks)
+			if tt.wantErr != 0 {
+				var hunkErr *HunkError
+				if !errors.As(err, &hunkErr) || hunkErr.N != tt.wantErr {
+					t.Fatalf("err = %v, want hunk #%d rejected", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		oldName string
+		newName string
+		wantErr bool
+	}{
+		{
+			name:    "git prefixes and timestamps",
+			patch:   "diff --git a/x.md b/x.md\n--- a/x.md\t2024-01-01\n+++ b/x.md\t2024-01-02\n@@ -1 +1 @@\n-a\n+b\n",
+			oldName: "x.md",
+			newName: "x.md",
+		},
+		{
+			name:    "new file",
+			patch:   "--- /dev/null\n+++ b/new.md\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+			oldName: DevNull,
+			newName: "new.md",
+		},
+		{
+			name:    "hunk shorter than its header",
+			patch:   "--- a/x.md\n+++ b/x.md\n@@ -1,3 +1,3 @@\n a\n-b\n+c\n",
+			wantErr: true,
+		},
+		{
+			name:    "no file changes",
+			patch:   "just some text\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patches, err := ParsePatch([]byte(tt.patch))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", patches)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(patches) != 1 || patches[0].OldName != tt.oldName || patches[0].NewName != tt.newName {
+				t.Errorf("got %+v, want %s -> %s", patches, tt.oldName, tt.newName)
+			}
+		})
+	}
+}
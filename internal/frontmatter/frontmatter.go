@@ -0,0 +1,123 @@
+package frontmatter
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const delimiter = "---"
+
+var namePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// ValidName reports whether name can name a skill or stack: lowercase
+// letters and digits in hyphen-separated words, so it is always a single,
+// safe path segment
+func ValidName(name string) bool {
+	return namePattern.MatchString(name)
+}
+
+// Frontmatter holds the YAML header of a SKILL.md file
+type Frontmatter struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Version     string `yaml:"version,omitempty"`
+
+	// Tags are free-form keywords shown by `info`
+	Tags StringList `yaml:"tags,omitempty"`
+
+	// Globs optionally limits the files the skill applies to, for agents
+	// that scope instructions by path (Cursor rules, Copilot instructions)
+	Globs StringList `yaml:"globs,omitempty"`
+
+	// Lines maps each top-level key to its 1-based line number in the file
+	Lines map[string]int `yaml:"-"`
+}
+
+// StringList accepts either a YAML list or a comma-separated string
+type StringList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (l *StringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, part := range strings.Split(value.Value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				*l = append(*l, part)
+			}
+		}
+		return nil
+	}
+
+	var items []string
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// Has reports whether a key is present in the frontmatter
+func (f *Frontmatter) Has(key string) bool {
+	_, ok := f.Lines[key]
+	return ok
+}
+
+// Parse splits a markdown document into its frontmatter and body.
+// Returns a nil Frontmatter when the document has no frontmatter block.
+func Parse(content []byte) (*Frontmatter, []byte, error) {
+	lines := bytes.SplitAfter(content, []byte("\n"))
+	if len(lines) == 0 || string(bytes.TrimRight(lines[0], "\r\n")) != delimiter {
+		return nil, content, nil
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if string(bytes.TrimRight(lines[i], "\r\n")) == delimiter {
+			end = i
+			break
+		}
+	}
+	if end == -1 {
+		return nil, content, fmt.Errorf("frontmatter is not closed with '%s'", delimiter)
+	}
+
+	header := bytes.Join(lines[1:end], nil)
+	body := bytes.Join(lines[end+1:], nil)
+
+	fm := &Frontmatter{Lines: make(map[string]int)}
+	if len(bytes.TrimSpace(header)) == 0 {
+		return fm, body, nil
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(header, &node); err != nil {
+		return nil, body, fmt.Errorf("invalid frontmatter: %w", err)
+	}
+	if err := node.Decode(fm); err != nil {
+		return nil, body, fmt.Errorf("invalid frontmatter: %w", err)
+	}
+
+	// Record key positions, offset by the opening delimiter line
+	if len(node.Content) > 0 && node.Content[0].Kind == yaml.MappingNode {
+		mapping := node.Content[0]
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			key := mapping.Content[i]
+			fm.Lines[key.Value] = key.Line + 1
+		}
+	}
+
+	return fm, body, nil
+}
+
+// Strip returns the document body without its frontmatter block
+func Strip(content []byte) []byte {
+	_, body, err := Parse(content)
+	if err != nil {
+		return content
+	}
+	return body
+}
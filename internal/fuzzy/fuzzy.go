@@ -0,0 +1,111 @@
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxSuggestions caps how many names Suggest returns
+const maxSuggestions = 3
+
+// Distance is the number of single-character insertions, deletions,
+// substitutions and transpositions of adjacent characters turning a into b
+func Distance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// Three rows of the edit matrix: two back for transpositions
+	prev2 := make([]int, len(t)+1)
+	prev := make([]int, len(t)+1)
+	cur := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		cur[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	return prev[len(t)]
+}
+
+// MaxDistance is the largest distance at which a word still looks like a
+// typo of another: one edit per three characters, between 1 and 3
+func MaxDistance(word string) int {
+	return min(max(len([]rune(word))/3, 1), 3)
+}
+
+// Suggest returns the candidates that name is most likely a typo of, closest
+// first. Candidates may be qualified as "stack/name". An unqualified name is
+// compared with the name part of each candidate and suggested in that form;
+// a qualified one is compared with the whole candidate, and also finds the
+// same name under another stack.
+func Suggest(name string, candidates []string) []string {
+	name = strings.ToLower(name)
+	qualified := strings.Contains(name, "/")
+	base := baseName(name)
+	limit := MaxDistance(base)
+
+	best := make(map[string]int)
+	for _, c := range candidates {
+		form := c
+		var d int
+		if qualified && strings.Contains(c, "/") {
+			lower := strings.ToLower(c)
+			d = min(Distance(name, lower), Distance(base, baseName(lower))+1)
+		} else {
+			form = baseName(c)
+			d = Distance(base, strings.ToLower(form))
+			// A prefix of a longer name, e.g. "clean" for "clean-architecture"
+			if len(base) >= 3 && strings.HasPrefix(strings.ToLower(form), base) {
+				d = min(d, limit)
+			}
+		}
+		if d > limit {
+			continue
+		}
+		if old, ok := best[form]; !ok || d < old {
+			best[form] = d
+		}
+	}
+
+	suggestions := make([]string, 0, len(best))
+	for form := range best {
+		suggestions = append(suggestions, form)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if best[a] != best[b] {
+			return best[a] < best[b]
+		}
+		return a < b
+	})
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return suggestions
+}
+
+// DidYouMean formats suggestions to append to an error message, e.g.
+// " (did you mean code-reviewer?)", or returns "" when there are none
+func DidYouMean(suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return " (did you mean " + suggestions[0] + "?)"
+	}
+	last := len(suggestions) - 1
+	return " (did you mean " + strings.Join(suggestions[:last], ", ") + " or " + suggestions[last] + "?)"
+}
+
+func baseName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
@@ -0,0 +1,108 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "", b: "abc", want: 3},
+		{a: "abc", b: "", want: 3},
+		{a: "review", b: "review", want: 0},
+		{a: "revew", b: "review", want: 1},   // Insertion
+		{a: "reviiew", b: "review", want: 1}, // Deletion
+		{a: "raview", b: "review", want: 1},  // Substitution
+		{a: "reivew", b: "review", want: 1},  // Transposition
+		{a: "ab", b: "ba", want: 1},
+		{a: "abcd", b: "badc", want: 2}, // Two transpositions
+		{a: "ca", b: "abc", want: 3},    // Restricted: no edits inside a transposed pair
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "naïve", b: "naive", want: 1}, // Runes, not bytes
+		{a: "ñó", b: "óñ", want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := Distance(tt.a, tt.b); got != tt.want {
+				t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := Distance(tt.b, tt.a); got != tt.want {
+				t.Errorf("Distance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxDistance(t *testing.T) {
+	for word, want := range map[string]int{"go": 1, "sql": 1, "review": 2, "reviewer": 2, "code-reviewer": 3, "clean-architecture": 3} {
+		if got := MaxDistance(word); got != want {
+			t.Errorf("MaxDistance(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{
+		"common/code-reviewer",
+		"go/testing",
+		"dotnet/testing",
+		"dotnet/clean-architecture",
+		"db/sql",
+		"db/sql-tuning",
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "transposition", in: "code-reivewer", want: []string{"code-reviewer"}},
+		{name: "case", in: "Code-Reviewer", want: []string{"code-reviewer"}},
+		{name: "same name in two stacks", in: "testnig", want: []string{"testing"}},
+		{name: "prefix", in: "clean", want: []string{"clean-architecture"}},
+		{name: "too far", in: "kubernetes", want: []string{}},
+		{name: "short names allow one edit", in: "sqk", want: []string{"sql"}},
+		{name: "qualified typo", in: "go/testnig", want: []string{"go/testing", "dotnet/testing"}},
+		{name: "qualified stack typo", in: "dotnte/testing", want: []string{"dotnet/testing", "go/testing"}},
+		{name: "qualified wrong stack", in: "common/sql", want: []string{"db/sql"}},
+		{name: "qualified case", in: "Common/Code-Reviewer", want: []string{"common/code-reviewer"}},
+		{name: "qualified too far", in: "go/kubernetes", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Suggest(tt.in, candidates); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Suggest(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestLimit(t *testing.T) {
+	got := Suggest("tst", []string{"a/test", "b/tests", "c/tat", "d/ttt", "e/tsx"})
+	if len(got) != maxSuggestions {
+		t.Errorf("got %q, want %d suggestions", got, maxSuggestions)
+	}
+}
+
+func TestDidYouMean(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want string
+	}{
+		{in: nil, want: ""},
+		{in: []string{"a"}, want: " (did you mean a?)"},
+		{in: []string{"a", "b"}, want: " (did you mean a or b?)"},
+		{in: []string{"a", "b", "c"}, want: " (did you mean a, b or c?)"},
+	}
+	for _, tt := range tests {
+		if got := DidYouMean(tt.in); got != tt.want {
+			t.Errorf("DidYouMean(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package installer
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Problems reported by Check
+const (
+	ProblemMissing    = "missing"    // Declared or recorded, but not installed
+	ProblemExtra      = "extra"      // Installed but not declared
+	ProblemModified   = "modified"   // Files differ from what was installed
+	ProblemUntracked  = "untracked"  // Installed without a manifest entry
+	ProblemLinked     = "linked"     // Linked from a local directory
+	ProblemStale      = "stale"      // The registry has a newer version, or the manifest differs from a pin
+	ProblemUnverified = "unverified" // Could not be compared with the registry
+)
+
+// Problem is one mismatch between the skills directory and its declaration
+type Problem struct {
+	Skill  string `json:"skill"`
+	Kind   string `json:"problem"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Check verifies the skills directory against the declared skills and the
+// manifest, without writing anything. The manifest serves as the lockfile:
+// the ref, commit, version and file hashes it records are held against the
+// config's pins and the files on disk.
+func (i *Installer) Check(declared []Spec) ([]Problem, error) {
+	installed, err := i.ListInstalled()
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := i.LoadManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []Problem
+	add := func(skill, kind, detail string) {
+		problems = append(problems, Problem{Skill: skill, Kind: kind, Detail: detail})
+	}
+
+	wanted := make(map[string]bool)
+	specs := make(map[string]Spec)
+	for _, spec := range declared {
+		name := i.resolveName(spec)
+		wanted[name] = true
+		specs[name] = spec
+	}
+	for name := range manifest.Skills {
+		if !i.IsInstalled(name) {
+			add(name, ProblemMissing, "recorded in "+ManifestFileName+" but not installed")
+		}
+	}
+	for name := range wanted {
+		if !i.IsInstalled(name) && manifest.Skills[name] == nil {
+			add(name, ProblemMissing, "declared but not installed")
+		}
+	}
+
+	for _, name := range installed {
+		if !wanted[name] {
+			add(name, ProblemExtra, "installed but not declared")
+		}
+
+		status, err := i.Status(name)
+		if err != nil {
+			return nil, err
+		}
+		switch status.State {
+		case StateLinked:
+			add(name, ProblemLinked, "linked skills point outside the project")
+			continue
+		case StateUntracked:
+			add(name, ProblemUntracked, "no entry in "+ManifestFileName)
+		case StateModified:
+			add(name, ProblemModified, describeDrift(status))
+		}
+
+		if spec, ok := specs[name]; ok {
+			// Skills declared through a stack follow the config's registry ref
+			if spec.Provider == nil {
+				spec.Provider = i.provider
+			}
+			if entry, tracked := i.ManifestEntry(name); tracked {
+				if drift := specDrift(spec, entry); drift != "" {
+					add(name, ProblemStale, drift)
+					continue
+				}
+			}
+		}
+
+		u, err := i.CheckUpdate(name)
+		switch {
+		case err != nil:
+			add(name, ProblemUnverified, err.Error())
+		case u.Outdated:
+			add(name, ProblemStale, fmt.Sprintf("%s → %s, %d file(s) changed", u.Current, u.Latest, len(u.Changed)))
+		}
+	}
+
+	sort.SliceStable(problems, func(a, b int) bool {
+		return problems[a].Skill < problems[b].Skill
+	})
+	return problems, nil
+}
+
+// resolveName maps a declared skill to its install name, falling back to the
+// last path element when the registry is unavailable
+func (i *Installer) resolveName(spec Spec) string {
+	if provider := i.providerOf(spec); provider != nil {
+		if skill, err := provider.Find(spec.Name); err == nil {
+			return skill.Name
+		}
+	}
+	return path.Base(spec.Name)
+}
+
+func describeDrift(s *SkillStatus) string {
+	var parts []string
+	for _, group := range []struct {
+		label string
+		files []string
+	}{{"modified", s.Modified}, {"added", s.Added}, {"deleted", s.Deleted}} {
+		if len(group.files) > 0 {
+			parts = append(parts, group.label+": "+strings.Join(group.files, ", "))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,70 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/frontmatter"
+)
+
+const (
+	IndexBegin = "<!-- vibe-skills:begin -->"
+	IndexEnd   = "<!-- vibe-skills:end -->"
+
+	// IndexFileName is the agent memory file the skill index is kept in
+	IndexFileName = "CLAUDE.md"
+)
+
+// SyncIndex rewrites the managed skill index block in the configured index
+// file from what is installed on disk. It is idempotent, leaves content
+// outside the markers untouched and removes the block when nothing is installed.
+func (i *Installer) SyncIndex() error {
+	if i.opts.IndexFile == "" {
+		return nil
+	}
+
+	installed, err := i.ListInstalled()
+	if err != nil {
+		return err
+	}
+
+	err = updateFile(i.opts.IndexFile, func(content string) (string, error) {
+		if len(installed) == 0 {
+			return removeBlock(content, IndexBegin, IndexEnd)
+		}
+		return upsertBlock(content, IndexBegin, IndexEnd, i.indexBody(installed))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update skill index: %w", err)
+	}
+	return nil
+}
+
+func (i *Installer) indexBody(installed []string) string {
+	var b strings.Builder
+	b.WriteString("## Installed Skills\n\n")
+	b.WriteString("_Managed by vibe-skills. Changes inside this block are overwritten._\n\n")
+
+	for _, name := range installed {
+		path := filepath.ToSlash(filepath.Join(TargetDir, name, "SKILL.md"))
+		if rel, err := filepath.Rel(filepath.Dir(i.opts.IndexFile), i.skillDir(name)); err == nil {
+			path = filepath.ToSlash(filepath.Join(rel, "SKILL.md"))
+		}
+
+		desc := ""
+		if data, err := os.ReadFile(filepath.Join(i.skillDir(name), "SKILL.md")); err == nil {
+			if fm, _, err := frontmatter.Parse(data); err == nil && fm != nil {
+				desc = strings.Join(strings.Fields(fm.Description), " ")
+			}
+		}
+
+		if desc != "" {
+			fmt.Fprintf(&b, "- **%s** (`%s`): %s\n", name, path, desc)
+		} else {
+			fmt.Fprintf(&b, "- **%s** (`%s`)\n", name, path)
+		}
+	}
+	return b.String()
+}
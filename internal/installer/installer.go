@@ -1,10 +1,13 @@
 package installer
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 
+	"github.com/cuongtl1992/vibe-skills/internal/fuzzy"
 	"github.com/cuongtl1992/vibe-skills/internal/registry"
 )
 
@@ -19,31 +22,130 @@ type SkillProvider interface {
 	GetFiles(skill *registry.Skill) (map[string][]byte, error)
 }
 
+// Options configures optional installer behaviour
+type Options struct {
+	Store   *Store   // Install into the shared store and link into the project
+	Targets []Target // Additional agent formats kept in sync with .claude/skills
+
+	// IndexFile, when set, gets a managed block listing installed skills
+	// (e.g. the project's CLAUDE.md)
+	IndexFile string
+
+	// ProviderFor returns a provider for the source recorded in a skill's
+	// manifest entry, so updates fetch from where the skill came from
+	ProviderFor ProviderFactory
+}
+
 type Installer struct {
-	provider SkillProvider
-	baseDir  string
+	provider  SkillProvider
+	baseDir   string
+	opts      Options
+	reason    string
+	strategy  string
+	dryRun    bool
+	plan      []*SkillPlan
+	planFiles []FileOp
 }
 
 func New(provider SkillProvider, baseDir string) *Installer {
-	return &Installer{
+	return NewWithOptions(provider, baseDir, nil)
+}
+
+// NewWithOptions creates an installer with optional behaviour enabled
+func NewWithOptions(provider SkillProvider, baseDir string, opts *Options) *Installer {
+	i := &Installer{
 		provider: provider,
 		baseDir:  baseDir,
 	}
+	if opts != nil {
+		i.opts = *opts
+	}
+	return i
+}
+
+// SetReason sets the install reason recorded in the manifest for
+// subsequent Install and InstallMultiple calls
+func (i *Installer) SetReason(reason string) {
+	i.reason = reason
+}
+
+// SetStrategy sets how Update handles conflicting local edits
+// (StrategyMerge by default)
+func (i *Installer) SetStrategy(strategy string) {
+	i.strategy = strategy
 }
 
 func (i *Installer) Install(skillName string) error {
-	skill, err := i.provider.Find(skillName)
+	reason := i.reason
+	if reason == "" {
+		reason = ReasonExplicit
+	}
+	return i.install(Spec{Name: skillName}, reason)
+}
+
+func (i *Installer) install(spec Spec, reason string) error {
+	provider := i.providerOf(spec)
+	skill, err := provider.Find(spec.Name)
 	if err != nil {
-		return fmt.Errorf("skill not found: %s", skillName)
+		return notFound(spec.Name, err)
+	}
+	if spec.Version != "" && skill.Version != spec.Version {
+		return fmt.Errorf("version %s required, but %s offers %s: pin a ref that has it", spec.Version, providerLabel(provider), orDash(skill.Version))
 	}
 
-	// Always install to folder: .claude/skills/{skill-name}/
-	skillDir := filepath.Join(i.baseDir, TargetDir, skill.Name)
+	if info, ok := i.LinkInfo(skill.Name); ok {
+		return fmt.Errorf("skill is linked to %s: run 'vibe-skills unlink %s' first", info.Source, skill.Name)
+	}
 
 	// Fetch all files (at minimum SKILL.md)
-	files, err := i.provider.GetFiles(skill)
+	files, err := i.fetchFiles(provider, skill, spec.With)
 	if err != nil {
-		return fmt.Errorf("failed to fetch skill files: %w", err)
+		return err
+	}
+
+	if i.dryRun {
+		targets, err := i.selectTargets(spec.Targets)
+		if err != nil {
+			return err
+		}
+		return i.recordPlan(ActionInstall, skill.Name, files, files, nil, targets)
+	}
+
+	entry := newEntry(skill, reason, spec.Targets, spec.With)
+	if err := i.apply(provider, skill, entry, files, files); err != nil {
+		return err
+	}
+	return i.recordConflicts(skill.Name, nil)
+}
+
+// newEntry starts the manifest entry for installing a skill
+func newEntry(skill *registry.Skill, reason string, targets, with []string) *ManifestEntry {
+	return &ManifestEntry{
+		Name:    skill.Name,
+		Stack:   skill.Stack,
+		Version: skill.Version,
+		Reason:  reason,
+		Targets: targets,
+		With:    with,
+	}
+}
+
+// apply writes a skill to .claude/skills/{skill-name}/ and records upstream as
+// the installed version. files differs from upstream when local edits were
+// merged in by an update.
+func (i *Installer) apply(provider SkillProvider, skill *registry.Skill, entry *ManifestEntry, files, upstream map[string][]byte) error {
+	if i.opts.Store != nil {
+		if err := i.installToStore(provider, skill.Name, upstream); err != nil {
+			return err
+		}
+		return i.finishInstall(provider, skill, entry, upstream, upstream)
+	}
+
+	// Replace the whole directory so files dropped upstream go away; this
+	// also never writes through a link into the shared store
+	skillDir := i.skillDir(skill.Name)
+	if err := os.RemoveAll(skillDir); err != nil {
+		return fmt.Errorf("failed to replace existing skill: %w", err)
 	}
 
 	for relPath, content := range files {
@@ -58,7 +160,10 @@ func (i *Installer) Install(skillName string) error {
 		}
 	}
 
-	return nil
+	if err := i.saveBase(skill.Name, upstream); err != nil {
+		return fmt.Errorf("failed to keep base copy: %w", err)
+	}
+	return i.finishInstall(provider, skill, entry, upstream, files)
 }
 
 func (i *Installer) InstallMultiple(skillNames []string) (installed []string, errors []error) {
@@ -79,12 +184,12 @@ func (i *Installer) InstallStack(stack string) (installed []string, errors []err
 		return
 	}
 	if len(skills) == 0 {
-		errors = append(errors, fmt.Errorf("no skills found in stack: %s", stack))
+		errors = append(errors, fmt.Errorf("no skills found in stack: %s%s", stack, i.suggestStack(stack)))
 		return
 	}
 
 	for _, skill := range skills {
-		if err := i.Install(skill.Name); err != nil {
+		if err := i.install(Spec{Name: skill.Name}, ReasonStack+":"+stack); err != nil {
 			errors = append(errors, err)
 		} else {
 			installed = append(installed, skill.Name)
@@ -93,6 +198,16 @@ func (i *Installer) InstallStack(stack string) (installed []string, errors []err
 	return
 }
 
+// suggestStack returns the "did you mean" suffix for an unknown stack
+func (i *Installer) suggestStack(stack string) string {
+	skills, _ := i.provider.List()
+	var stacks []string
+	for _, s := range skills {
+		stacks = append(stacks, s.Stack)
+	}
+	return fuzzy.DidYouMean(fuzzy.Suggest(stack, stacks))
+}
+
 func (i *Installer) InstallAll() (installed []string, errors []error) {
 	skills, err := i.provider.List()
 	if err != nil {
@@ -101,7 +216,7 @@ func (i *Installer) InstallAll() (installed []string, errors []error) {
 	}
 
 	for _, skill := range skills {
-		if err := i.Install(skill.Name); err != nil {
+		if err := i.install(Spec{Name: skill.Name}, ReasonAll); err != nil {
 			errors = append(errors, err)
 		} else {
 			installed = append(installed, skill.Name)
@@ -111,21 +226,76 @@ func (i *Installer) InstallAll() (installed []string, errors []error) {
 }
 
 func (i *Installer) Remove(skillName string) error {
-	dirPath := filepath.Join(i.baseDir, TargetDir, skillName)
+	dirPath := i.skillDir(skillName)
+
+	// Linked skills: delete the link only, never the source directory
+	if info, ok := i.LinkInfo(skillName); ok {
+		if i.dryRun {
+			i.plan = append(i.plan, &SkillPlan{
+				Skill:  skillName,
+				Action: ActionRemove,
+				Files:  []FileOp{{Op: OpDelete, Path: path.Join(TargetDir, skillName)}},
+			})
+			return nil
+		}
+		if err := removeLink(dirPath, info); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(i.linkBackup(skillName)); err != nil {
+			return err
+		}
+		return i.SyncIndex()
+	}
 
 	// Check if skill directory exists
 	info, err := os.Stat(dirPath)
 	if os.IsNotExist(err) {
-		return fmt.Errorf("skill not installed: %s", skillName)
+		return i.notInstalled(skillName)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to check skill: %w", err)
 	}
 	if !info.IsDir() {
-		return fmt.Errorf("skill not installed: %s", skillName)
+		return i.notInstalled(skillName)
+	}
+
+	if i.dryRun {
+		return i.recordPlan(ActionRemove, skillName, nil, nil, nil, nil)
+	}
+
+	if err := os.RemoveAll(dirPath); err != nil {
+		return err
+	}
+	if err := i.removeTargets(skillName); err != nil {
+		return err
+	}
+	if err := i.forget(skillName); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
 	}
+	if err := os.RemoveAll(i.basePath(skillName)); err != nil {
+		return err
+	}
+	if err := i.recordConflicts(skillName, nil); err != nil {
+		return err
+	}
+	return i.SyncIndex()
+}
+
+// notInstalled is the error for a skill name that is not installed, naming
+// the installed skills it may be a typo of
+func (i *Installer) notInstalled(name string) error {
+	installed, _ := i.ListInstalled()
+	return fmt.Errorf("skill not installed: %s%s", name, fuzzy.DidYouMean(fuzzy.Suggest(name, installed)))
+}
 
-	return os.RemoveAll(dirPath)
+// notFound is the error for a skill a provider cannot find, keeping the
+// suggestions of a registry lookup
+func notFound(name string, err error) error {
+	var nf *registry.NotFoundError
+	if errors.As(err, &nf) {
+		return nf
+	}
+	return fmt.Errorf("skill not found: %s", name)
 }
 
 func (i *Installer) ListInstalled() ([]string, error) {
@@ -141,7 +311,8 @@ func (i *Installer) ListInstalled() ([]string, error) {
 
 	var installed []string
 	for _, entry := range entries {
-		if entry.IsDir() {
+		// Linked skills are symlinks to directories
+		if entry.IsDir() || entry.Type()&os.ModeSymlink != 0 {
 			// Skill directory: check for SKILL.md inside
 			skillMd := filepath.Join(targetDir, entry.Name(), "SKILL.md")
 			if _, err := os.Stat(skillMd); err == nil {
@@ -154,7 +325,7 @@ func (i *Installer) ListInstalled() ([]string, error) {
 }
 
 func (i *Installer) IsInstalled(skillName string) bool {
-	dirPath := filepath.Join(i.baseDir, TargetDir, skillName)
+	dirPath := i.skillDir(skillName)
 	info, err := os.Stat(dirPath)
 	if err != nil || !info.IsDir() {
 		return false
@@ -167,16 +338,118 @@ func (i *Installer) IsInstalled(skillName string) bool {
 }
 
 func (i *Installer) Update(skillName string) error {
+	_, err := i.UpdateSkill(skillName)
+	return err
+}
+
+// UpdateSkill updates a skill from the source recorded at install time,
+// three-way merging local edits using the configured strategy
+func (i *Installer) UpdateSkill(skillName string) (*UpdateResult, error) {
+	return i.updateSkill(skillName, nil)
+}
+
+// updateSkill updates a skill from the source and with the settings recorded
+// at install time, or from those of spec when given
+func (i *Installer) updateSkill(skillName string, spec *Spec) (*UpdateResult, error) {
 	if !i.IsInstalled(skillName) {
-		return fmt.Errorf("skill not installed: %s", skillName)
+		return nil, i.notInstalled(skillName)
+	}
+
+	result := &UpdateResult{Name: skillName}
+
+	// Linked skills track their local source instead of the registry
+	if info, ok := i.LinkInfo(skillName); ok {
+		if i.dryRun {
+			return result, nil
+		}
+		return result, i.refreshLink(info)
+	}
+
+	reason := ReasonExplicit
+	entry, tracked := i.ManifestEntry(skillName)
+	if !tracked {
+		entry = &ManifestEntry{}
+	} else if entry.Reason != "" {
+		reason = entry.Reason
+	}
+	targets, with := entry.Targets, entry.With
+
+	var provider SkillProvider
+	if spec != nil {
+		provider, targets, with = i.providerOf(*spec), spec.Targets, spec.With
+	} else {
+		var err error
+		if provider, err = i.UpdateSource(skillName); err != nil {
+			return nil, err
+		}
+	}
+	skill, err := provider.Find(skillName)
+	if err != nil {
+		return nil, notFound(skillName, err)
+	}
+	if spec != nil && spec.Version != "" && skill.Version != spec.Version {
+		return nil, fmt.Errorf("version %s required, but %s offers %s: pin a ref that has it", spec.Version, providerLabel(provider), orDash(skill.Version))
+	}
+	upstream, err := i.fetchFiles(provider, skill, with)
+	if err != nil {
+		return nil, err
+	}
+
+	if tracked {
+		result.Changed = changedFiles(entry.Files, HashFiles(upstream))
+		result.UpToDate = len(result.Changed) == 0 && (spec == nil || specDrift(*spec, entry) == "")
+	}
+
+	// Nothing new upstream: leave the files alone, but bring agent targets
+	// and the index in line with current settings
+	if result.UpToDate && (i.opts.Store != nil) == i.isStoreManaged(skillName) {
+		local, err := i.ReadSkillFiles(skillName)
+		if err != nil {
+			return nil, err
+		}
+		selected, err := i.selectTargets(targets)
+		if err != nil {
+			return nil, err
+		}
+		if i.dryRun {
+			return result, i.recordPlan(ActionUpdate, skillName, local, nil, nil, selected)
+		}
+		if err := i.syncTargets(skill, local, selected, entry.Written); err != nil {
+			return nil, err
+		}
+		if tracked {
+			if err := i.recordWritten(skillName, targetNames(selected)); err != nil {
+				return nil, fmt.Errorf("failed to update manifest: %w", err)
+			}
+		}
+		return result, i.SyncIndex()
+	}
+
+	// Store entries are shared between projects, so they are never edited
+	// in place and there is nothing local to merge
+	files := upstream
+	if i.opts.Store == nil && !i.isStoreManaged(skillName) {
+		files, err = i.reconcile(skillName, upstream, result)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Remove old and install new
-	if err := i.Remove(skillName); err != nil {
-		return fmt.Errorf("failed to remove old skill: %w", err)
+	if i.dryRun {
+		selected, err := i.selectTargets(targets)
+		if err != nil {
+			return nil, err
+		}
+		return result, i.recordPlan(ActionUpdate, skillName, files, upstream, result.Conflicts, selected)
 	}
 
-	return i.Install(skillName)
+	if err := i.apply(provider, skill, newEntry(skill, reason, targets, with), files, upstream); err != nil {
+		return nil, err
+	}
+	if err := i.recordConflicts(skillName, result.Conflicts); err != nil {
+		return nil, fmt.Errorf("failed to record conflicts: %w", err)
+	}
+	return result, nil
 }
 
 func (i *Installer) UpdateAll() (updated []string, errors []error) {
@@ -199,3 +472,200 @@ func (i *Installer) UpdateAll() (updated []string, errors []error) {
 	}
 	return
 }
+
+// UpdateSource returns the provider an update of a skill fetches from: the
+// source recorded at install time, or the installer's provider
+func (i *Installer) UpdateSource(skillName string) (SkillProvider, error) {
+	entry, ok := i.ManifestEntry(skillName)
+	if !ok || i.opts.ProviderFor == nil || entry.Source == "" {
+		return i.provider, nil
+	}
+	provider, err := i.opts.ProviderFor(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use recorded source %s: %w", entry.Source, err)
+	}
+	return provider, nil
+}
+
+// Fetch returns the files installing a skill from provider would write,
+// with the project's overlay applied
+func (i *Installer) Fetch(provider SkillProvider, skillName string) (map[string][]byte, error) {
+	_, files, err := i.fetch(provider, skillName)
+	return files, err
+}
+
+func (i *Installer) fetch(provider SkillProvider, skillName string) (*registry.Skill, map[string][]byte, error) {
+	skill, err := provider.Find(skillName)
+	if err != nil {
+		return nil, nil, notFound(skillName, err)
+	}
+	var with []string
+	if entry, ok := i.ManifestEntry(skillName); ok {
+		with = entry.With
+	}
+	files, err := i.fetchFiles(provider, skill, with)
+	if err != nil {
+		return nil, nil, err
+	}
+	return skill, files, nil
+}
+
+// fetchFiles fetches a skill's files, keeps the selected optional components
+// and applies the project overlay, which is part of what gets installed and
+// so never mistaken for a local edit
+func (i *Installer) fetchFiles(provider SkillProvider, skill *registry.Skill, with []string) (map[string][]byte, error) {
+	files, err := provider.GetFiles(skill)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch skill files: %w", err)
+	}
+	return i.applyOverlay(skill.Name, withComponents(files, with))
+}
+
+// SkillDir returns the directory a skill is installed to, whether or not it exists
+func (i *Installer) SkillDir(name string) string {
+	return i.skillDir(name)
+}
+
+// skillDir returns the install directory for a skill
+func (i *Installer) skillDir(name string) string {
+	return filepath.Join(i.baseDir, TargetDir, name)
+}
+
+// installToStore puts the files in the shared store and links them into the project
+func (i *Installer) installToStore(provider SkillProvider, name string, files map[string][]byte) error {
+	store := i.opts.Store
+	entry, err := store.Put(name, files)
+	if err != nil {
+		return fmt.Errorf("failed to store skill: %w", err)
+	}
+
+	skillDir := i.skillDir(name)
+	if err := os.RemoveAll(skillDir); err != nil {
+		return fmt.Errorf("failed to replace existing skill: %w", err)
+	}
+	if err := store.LinkInto(name, providerSource(provider), providerRef(provider), entry, skillDir); err != nil {
+		return fmt.Errorf("failed to link skill from store: %w", err)
+	}
+
+	return store.Register(filepath.Join(i.baseDir, TargetDir))
+}
+
+// isStoreManaged reports whether an installed skill links into the shared store
+func (i *Installer) isStoreManaged(name string) bool {
+	return len(i.store().references(i.skillDir(name))) > 0
+}
+
+// store returns the configured store, or the default one for detection
+func (i *Installer) store() *Store {
+	if i.opts.Store != nil {
+		return i.opts.Store
+	}
+	return NewStore("")
+}
+
+// providerRef returns the registry ref of a provider, if it exposes one
+func providerRef(provider SkillProvider) string {
+	if p, ok := provider.(interface{ GetRef() string }); ok {
+		return p.GetRef()
+	}
+	return ""
+}
+
+// providerSource returns the registry a provider reads from, if it exposes one
+func providerSource(provider SkillProvider) string {
+	if p, ok := provider.(interface{ Source() string }); ok {
+		return p.Source()
+	}
+	return ""
+}
+
+// finishInstall records provenance of the upstream files and brings agent
+// targets and the skill index up to date with the installed files
+func (i *Installer) finishInstall(provider SkillProvider, skill *registry.Skill, entry *ManifestEntry, upstream, files map[string][]byte) error {
+	targets, err := i.selectTargets(entry.Targets)
+	if err != nil {
+		return err
+	}
+	// Read what was written before recordInstall replaces the entry
+	var previous []string
+	if old, ok := i.ManifestEntry(entry.Name); ok {
+		previous = old.Written
+	}
+	entry.Written = targetNames(targets)
+
+	if err := i.recordInstall(provider, entry, upstream); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if err := i.syncTargets(skill, files, targets, previous); err != nil {
+		return err
+	}
+	return i.SyncIndex()
+}
+
+// selectTargets returns the configured agent targets, or the skill's own
+// targets when its config entry sets them
+func (i *Installer) selectTargets(names []string) ([]Target, error) {
+	if len(names) == 0 {
+		return i.opts.Targets, nil
+	}
+	return NewTargets(names, i.baseDir)
+}
+
+// targetNames lists the targets that get files of their own, for the
+// manifest: the .claude/skills copy is tracked separately
+func targetNames(targets []Target) []string {
+	var names []string
+	for _, t := range targets {
+		if t.Name() != TargetClaude {
+			names = append(names, t.Name())
+		}
+	}
+	return names
+}
+
+// syncTargets writes the skill to the selected targets and removes it from
+// the targets it was written to before (previous) but are no longer
+// selected. Files of targets never written to, such as hand-written rules
+// sharing the skill's name, are left alone.
+func (i *Installer) syncTargets(skill *registry.Skill, files map[string][]byte, targets []Target, previous []string) error {
+	selected := make(map[string]bool)
+	for _, t := range targets {
+		selected[t.Name()] = true
+		if err := t.Sync(skill, files); err != nil {
+			return fmt.Errorf("failed to install for %s: %w", t.Name(), err)
+		}
+	}
+
+	for _, name := range previous {
+		if selected[name] {
+			continue
+		}
+		t, err := NewTarget(name, i.baseDir)
+		if err != nil {
+			return err
+		}
+		if err := t.Remove(skill.Name); err != nil {
+			return fmt.Errorf("failed to remove from %s: %w", t.Name(), err)
+		}
+	}
+	return nil
+}
+
+// removeTargets deletes the skill from every target the manifest records it
+// was written to, so a skill never outlives its .claude/skills copy
+func (i *Installer) removeTargets(name string) error {
+	entry, ok := i.ManifestEntry(name)
+	if !ok {
+		return nil
+	}
+	targets, err := NewTargets(entry.Written, i.baseDir)
+	if err != nil {
+		return err
+	}
+	for _, t := range targets {
+		if err := t.Remove(name); err != nil {
+			return fmt.Errorf("failed to remove from %s: %w", t.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package installer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/frontmatter"
+)
+
+const (
+	// StateDir holds per-project installer state (backups, base copies)
+	StateDir = ".vibe-skills"
+
+	// linkMarker is written into mirrored skills to record their source
+	linkMarker = ".vibe-skills-link"
+
+	linkBackupDir = "linked"
+
+	// stateIgnoreFile keeps the machine-local parts of StateDir out of git
+	stateIgnoreFile = ".gitignore"
+)
+
+// stateIgnored lists the parts of StateDir the installer rebuilds on its own.
+// Overlays are left out: they are meant to be committed with the project.
+var stateIgnored = []string{baseCopyDir + "/", linkBackupDir + "/", conflictsFileName}
+
+// LinkInfo describes a skill linked from a local directory
+type LinkInfo struct {
+	Name     string
+	Source   string
+	Mirrored bool // Copied because symlinks are unavailable
+}
+
+// Link symlinks a local skill directory into the skills directory, falling back
+// to a mirrored copy where symlinks are unavailable. An installed registry copy
+// is moved aside and restored by Unlink.
+func (i *Installer) Link(srcDir string) (*LinkInfo, error) {
+	src, err := filepath.Abs(srcDir)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(filepath.Join(src, "SKILL.md"))
+	if err != nil {
+		return nil, fmt.Errorf("not a skill directory (no SKILL.md): %s", srcDir)
+	}
+
+	name := filepath.Base(src)
+	if fm, _, err := frontmatter.Parse(content); err == nil && fm != nil && fm.Name != "" {
+		name = fm.Name
+	}
+	if !frontmatter.ValidName(name) {
+		return nil, fmt.Errorf("invalid skill name %q: use lowercase letters, digits and hyphens", name)
+	}
+
+	target := i.skillDir(name)
+	if existing, ok := i.LinkInfo(name); ok {
+		// Re-linking: drop the old link but keep any backup
+		if err := removeLink(target, existing); err != nil {
+			return nil, err
+		}
+	} else if _, err := os.Lstat(target); err == nil {
+		backup := i.linkBackup(name)
+		if err := os.RemoveAll(backup); err != nil {
+			return nil, err
+		}
+		if err := i.ignoreState(); err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(backup), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(target, backup); err != nil {
+			return nil, fmt.Errorf("failed to back up installed skill: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return nil, err
+	}
+
+	info := &LinkInfo{Name: name, Source: src}
+	if err := os.Symlink(src, target); err != nil {
+		if err := mirrorDir(src, target); err != nil {
+			return nil, fmt.Errorf("failed to link skill: %w", err)
+		}
+		info.Mirrored = true
+	}
+	return info, i.SyncIndex()
+}
+
+// Unlink removes a linked skill and restores the registry copy it replaced.
+// Returns true if a previous copy was restored.
+func (i *Installer) Unlink(name string) (bool, error) {
+	info, ok := i.LinkInfo(name)
+	if !ok {
+		return false, fmt.Errorf("skill is not linked: %s", name)
+	}
+
+	target := i.skillDir(name)
+	if err := removeLink(target, info); err != nil {
+		return false, err
+	}
+
+	backup := i.linkBackup(name)
+	if _, err := os.Stat(backup); err != nil {
+		return false, i.SyncIndex()
+	}
+	if err := os.Rename(backup, target); err != nil {
+		return false, fmt.Errorf("failed to restore installed skill: %w", err)
+	}
+	return true, i.SyncIndex()
+}
+
+// LinkInfo reports whether a skill is linked and where it points
+func (i *Installer) LinkInfo(name string) (*LinkInfo, bool) {
+	target := i.skillDir(name)
+	fi, err := os.Lstat(target)
+	if err != nil || i.isStoreManaged(name) {
+		return nil, false
+	}
+
+	if fi.Mode()&os.ModeSymlink != 0 {
+		src, err := os.Readlink(target)
+		if err != nil {
+			return nil, false
+		}
+		return &LinkInfo{Name: name, Source: src}, true
+	}
+
+	data, err := os.ReadFile(filepath.Join(target, linkMarker))
+	if err != nil {
+		return nil, false
+	}
+	return &LinkInfo{Name: name, Source: strings.TrimSpace(string(data)), Mirrored: true}, true
+}
+
+// IsLinked reports whether a skill is linked from a local directory
+func (i *Installer) IsLinked(name string) bool {
+	_, ok := i.LinkInfo(name)
+	return ok
+}
+
+// refreshLink re-copies a mirrored skill from its source; symlinks are always live
+func (i *Installer) refreshLink(info *LinkInfo) error {
+	if !info.Mirrored {
+		return nil
+	}
+	target := i.skillDir(info.Name)
+	if err := os.RemoveAll(target); err != nil {
+		return err
+	}
+	return mirrorDir(info.Source, target)
+}
+
+// ignoreState adds the entries of stateIgnored missing from StateDir's
+// .gitignore, keeping whatever else the file contains
+func (i *Installer) ignoreState() error {
+	path := filepath.Join(i.baseDir, StateDir, stateIgnoreFile)
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	present := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		present[strings.TrimSpace(line)] = true
+	}
+	text := string(content)
+	for _, entry := range stateIgnored {
+		if present[entry] {
+			continue
+		}
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		text += entry + "\n"
+	}
+	if text == string(content) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(text), 0644)
+}
+
+func (i *Installer) linkBackup(name string) string {
+	return filepath.Join(i.baseDir, StateDir, linkBackupDir, name)
+}
+
+// removeLink deletes the link itself, never the content it points to
+func removeLink(target string, info *LinkInfo) error {
+	if info.Mirrored {
+		return os.RemoveAll(target)
+	}
+	return os.Remove(target)
+}
+
+// mirrorDir copies src into dst and records the source in a marker file
+func mirrorDir(src, dst string) error {
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		out := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(out, 0755)
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(out, data, 0644)
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dst, linkMarker), []byte(src+"\n"), 0644)
+}
@@ -0,0 +1,186 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	// ManifestFileName records provenance for every skill in a skills directory
+	ManifestFileName = ".vibe-skills-manifest.json"
+	manifestVersion  = 1
+)
+
+// Install reasons recorded in the manifest
+const (
+	ReasonExplicit = "explicit" // Named on the command line
+	ReasonConfig   = "config"   // Listed in .vibe-skills.yaml or the global config
+	ReasonStack    = "stack"    // Installed with --stack
+	ReasonAll      = "all"      // Installed with --all
+	ReasonBundle   = "bundle"   // Installed with --bundle
+)
+
+// Manifest records where each installed skill came from
+type Manifest struct {
+	Version int                       `json:"version"`
+	Skills  map[string]*ManifestEntry `json:"skills"`
+}
+
+// ManifestEntry is the provenance of one installed skill
+type ManifestEntry struct {
+	Name        string            `json:"name"`
+	Stack       string            `json:"stack,omitempty"`
+	Source      string            `json:"source,omitempty"` // Registry the skill was fetched from, e.g. github.com/owner/repo
+	Ref         string            `json:"ref,omitempty"`    // Branch, tag or commit requested
+	Commit      string            `json:"commit,omitempty"` // Commit the ref resolved to at install time
+	Version     string            `json:"version,omitempty"`
+	InstalledAt time.Time         `json:"installed_at"`
+	Reason      string            `json:"reason,omitempty"`
+	Targets     []string          `json:"targets,omitempty"` // Agent formats set for this skill in the config
+	With        []string          `json:"with,omitempty"`    // Optional components set in the config
+	Written     []string          `json:"written,omitempty"` // Agent targets the skill was written to, other than .claude/skills
+	Files       map[string]string `json:"files"`             // Relative path -> sha256
+}
+
+// SourceProvider is implemented by providers that can describe their origin
+type SourceProvider interface {
+	Source() string
+	GetRef() string
+	ResolveCommit() (string, error)
+}
+
+// ProviderFactory creates a provider for the source and ref recorded in a manifest entry
+type ProviderFactory func(entry *ManifestEntry) (SkillProvider, error)
+
+// LoadManifest reads the manifest of the installer's skills directory.
+// A missing manifest yields an empty one.
+func (i *Installer) LoadManifest() (*Manifest, error) {
+	m := &Manifest{Version: manifestVersion, Skills: make(map[string]*ManifestEntry)}
+
+	data, err := os.ReadFile(i.manifestPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFileName, err)
+	}
+	if m.Skills == nil {
+		m.Skills = make(map[string]*ManifestEntry)
+	}
+	return m, nil
+}
+
+// ManifestEntry returns the recorded provenance of a skill, if any
+func (i *Installer) ManifestEntry(name string) (*ManifestEntry, bool) {
+	m, err := i.LoadManifest()
+	if err != nil {
+		return nil, false
+	}
+	entry, ok := m.Skills[name]
+	return entry, ok
+}
+
+func (i *Installer) saveManifest(m *Manifest) error {
+	path := i.manifestPath()
+	if len(m.Skills) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// recordInstall stores provenance for a freshly installed skill
+func (i *Installer) recordInstall(provider SkillProvider, entry *ManifestEntry, files map[string][]byte) error {
+	m, err := i.LoadManifest()
+	if err != nil {
+		return err
+	}
+
+	if sp, ok := provider.(SourceProvider); ok {
+		entry.Source = sp.Source()
+		entry.Ref = sp.GetRef()
+		// Best-effort: the commit is informational when offline or rate-limited
+		entry.Commit, _ = sp.ResolveCommit()
+	}
+	entry.InstalledAt = time.Now().UTC().Truncate(time.Second)
+	entry.Files = HashFiles(files)
+
+	m.Skills[entry.Name] = entry
+	return i.saveManifest(m)
+}
+
+// recordWritten updates the agent targets a tracked skill was written to
+func (i *Installer) recordWritten(name string, written []string) error {
+	m, err := i.LoadManifest()
+	if err != nil {
+		return err
+	}
+	entry, ok := m.Skills[name]
+	if !ok || slices.Equal(entry.Written, written) {
+		return nil
+	}
+	entry.Written = written
+	return i.saveManifest(m)
+}
+
+// forget drops a skill from the manifest
+func (i *Installer) forget(name string) error {
+	m, err := i.LoadManifest()
+	if err != nil {
+		return err
+	}
+	if _, ok := m.Skills[name]; !ok {
+		return nil
+	}
+	delete(m.Skills, name)
+	return i.saveManifest(m)
+}
+
+func (i *Installer) manifestPath() string {
+	return filepath.Join(i.baseDir, TargetDir, ManifestFileName)
+}
+
+// HashFiles returns the sha256 of each file, keyed by relative path
+func HashFiles(files map[string][]byte) map[string]string {
+	hashes := make(map[string]string, len(files))
+	for p, content := range files {
+		hashes[p] = HashBytes(content)
+	}
+	return hashes
+}
+
+// HashBytes returns the hex sha256 of content
+func HashBytes(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// SortedNames returns the manifest's skill names in order
+func (m *Manifest) SortedNames() []string {
+	names := make([]string, 0, len(m.Skills))
+	for name := range m.Skills {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
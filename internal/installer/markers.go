@@ -0,0 +1,103 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// findBlock locates a marked block, returning start = -1 when there is none.
+// Unbalanced markers are an error rather than a guess: a begin marker with
+// no end after it, or an end marker on its own, would otherwise mean
+// replacing or duplicating text the user wrote.
+func findBlock(content, begin, end string) (start, stop int, err error) {
+	start = strings.Index(content, begin)
+	firstEnd := strings.Index(content, end)
+	switch {
+	case start == -1 && firstEnd == -1:
+		return -1, -1, nil
+	case start == -1:
+		return 0, 0, fmt.Errorf("found %q without %q before it", end, begin)
+	case firstEnd != -1 && firstEnd < start:
+		return 0, 0, fmt.Errorf("found %q before %q", end, begin)
+	}
+
+	stop = strings.Index(content[start:], end)
+	if stop == -1 {
+		return 0, 0, fmt.Errorf("found %q without %q after it", begin, end)
+	}
+	return start, start + stop + len(end), nil
+}
+
+// upsertBlock replaces the text between begin and end markers, or appends a
+// new marked block if none exists. Content outside the markers is untouched.
+func upsertBlock(content, begin, end, body string) (string, error) {
+	block := begin + "\n" + body
+	if !strings.HasSuffix(body, "\n") {
+		block += "\n"
+	}
+	block += end
+
+	start, stop, err := findBlock(content, begin, end)
+	if err != nil {
+		return "", err
+	}
+	if start == -1 {
+		if content == "" {
+			return block + "\n", nil
+		}
+		if !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		return content + "\n" + block + "\n", nil
+	}
+	return content[:start] + block + content[stop:], nil
+}
+
+// removeBlock deletes a marked block and the blank line separating it
+func removeBlock(content, begin, end string) (string, error) {
+	start, stop, err := findBlock(content, begin, end)
+	if err != nil || start == -1 {
+		return content, err
+	}
+
+	before := strings.TrimRight(content[:start], "\n")
+	after := strings.TrimLeft(content[stop:], "\n")
+	switch {
+	case before == "":
+		return after, nil
+	case after == "":
+		return before + "\n", nil
+	default:
+		return before + "\n\n" + after, nil
+	}
+}
+
+// updateFile rewrites a file through fn, deleting it when the result is empty.
+// When fn fails the file is left as it was.
+func updateFile(path string, fn func(string) (string, error)) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	updated, err := fn(string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if updated == string(data) {
+		return nil
+	}
+	if strings.TrimSpace(updated) == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(updated), 0644)
+}
@@ -0,0 +1,299 @@
+package installer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cuongtl1992/vibe-skills/internal/diff"
+)
+
+// Update strategies for skills with local edits
+const (
+	StrategyMerge  = "merge"  // Three-way merge, leaving conflict markers
+	StrategyOurs   = "ours"   // Three-way merge, keeping local changes on conflict
+	StrategyTheirs = "theirs" // Three-way merge, taking upstream changes on conflict
+)
+
+// Strategies lists the valid update strategies
+var Strategies = []string{StrategyMerge, StrategyOurs, StrategyTheirs}
+
+const (
+	baseCopyDir       = "base"
+	conflictsFileName = "conflicts.json"
+)
+
+// Conflict reasons
+const (
+	ConflictContent       = "both modified"
+	ConflictBinary        = "both modified (binary)"
+	ConflictDeletedLocal  = "deleted locally, modified upstream"
+	ConflictDeletedRemote = "modified locally, deleted upstream"
+)
+
+// UpdateResult describes how local edits were reconciled with upstream
+type UpdateResult struct {
+	Name      string
+	UpToDate  bool       // Upstream matched the installed version; nothing was rewritten
+	Changed   []string   // Files that changed upstream since the last install
+	Merged    []string   // Files whose local edits were kept or merged cleanly
+	Resolved  []string   // Files with conflicts settled by the ours/theirs strategy
+	Conflicts []Conflict // Files left for the user to resolve
+}
+
+// Conflict is an unresolved file left by a merge update
+type Conflict struct {
+	File    string `json:"file"`
+	Reason  string `json:"reason"`
+	Markers bool   `json:"markers,omitempty"` // Conflict markers were written into the file
+	Hash    string `json:"hash,omitempty"`    // Local content left in place, for conflicts without markers
+}
+
+// ValidStrategy reports whether s names an update strategy
+func ValidStrategy(s string) bool {
+	for _, v := range Strategies {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcile merges local edits to an installed skill into the upstream files
+func (i *Installer) reconcile(name string, upstream map[string][]byte, result *UpdateResult) (map[string][]byte, error) {
+	if s, err := i.Status(name); err == nil && s.State == StateClean {
+		return upstream, nil
+	}
+
+	local, err := i.ReadSkillFiles(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local files: %w", err)
+	}
+	// Without a base every differing file is a conflict
+	base, err := i.FetchInstalledVersion(name)
+	if err != nil {
+		base = nil
+	}
+
+	strategy := i.strategy
+	if strategy == "" {
+		strategy = StrategyMerge
+	}
+
+	paths := make(map[string]bool)
+	for _, files := range []map[string][]byte{base, local, upstream} {
+		for p := range files {
+			paths[p] = true
+		}
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	out := make(map[string][]byte)
+	for _, p := range sorted {
+		b, inBase := base[p]
+		l, inLocal := local[p]
+		u, inUp := upstream[p]
+
+		switch {
+		case inLocal && inUp && bytes.Equal(l, u):
+			out[p] = u
+		case inBase && inLocal && bytes.Equal(l, b):
+			// Unchanged locally: follow upstream, including deletion
+			if inUp {
+				out[p] = u
+			}
+		case inBase && !inLocal && !inUp:
+			// Deleted on both sides
+		case inBase && inUp && bytes.Equal(u, b):
+			// Unchanged upstream: keep the local edit or deletion
+			if inLocal {
+				out[p] = l
+			}
+			result.Merged = append(result.Merged, p)
+		case !inBase && inLocal && !inUp:
+			out[p] = l
+			result.Merged = append(result.Merged, p)
+		case !inBase && !inLocal && inUp:
+			out[p] = u
+		case inLocal && inUp && isText(l) && isText(u) && isText(b):
+			merged := diff.Merge3(b, l, u, strategy)
+			out[p] = merged.Content
+			switch {
+			case merged.Conflicts == 0:
+				result.Merged = append(result.Merged, p)
+			case strategy == StrategyMerge:
+				result.Conflicts = append(result.Conflicts, Conflict{File: p, Reason: ConflictContent, Markers: true})
+			default:
+				result.Resolved = append(result.Resolved, p)
+			}
+		default:
+			// Binary files and modify/delete conflicts cannot carry markers
+			keepLocal := strategy != StrategyTheirs
+			if keepLocal && inLocal {
+				out[p] = l
+			} else if !keepLocal && inUp {
+				out[p] = u
+			}
+			if strategy != StrategyMerge {
+				result.Resolved = append(result.Resolved, p)
+				continue
+			}
+			c := Conflict{File: p, Reason: ConflictBinary}
+			switch {
+			case !inLocal:
+				c.Reason = ConflictDeletedLocal
+			case !inUp:
+				c.Reason = ConflictDeletedRemote
+			}
+			if inLocal {
+				c.Hash = HashBytes(l)
+			}
+			result.Conflicts = append(result.Conflicts, c)
+		}
+	}
+	return out, nil
+}
+
+// isText reports whether content can be merged line by line
+func isText(content []byte) bool {
+	return !diff.IsBinary(content)
+}
+
+// basePath returns where the pristine copy of an installed skill is kept
+func (i *Installer) basePath(name string) string {
+	return filepath.Join(i.baseDir, StateDir, baseCopyDir, name)
+}
+
+// saveBase keeps the installed upstream files as the base for future merges
+func (i *Installer) saveBase(name string, files map[string][]byte) error {
+	dir := i.basePath(name)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := i.ignoreState(); err != nil {
+		return err
+	}
+	for relPath, content := range files {
+		fullPath := filepath.Join(dir, relPath)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fullPath, content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBase reads the base copy of a skill, if one was kept
+func (i *Installer) readBase(name string) (map[string][]byte, bool) {
+	dir := i.basePath(name)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, false
+	}
+	files, err := readTree(dir)
+	if err != nil {
+		return nil, false
+	}
+	return files, true
+}
+
+// Conflicts returns the unresolved conflicts left by merge updates, keyed by
+// skill. Conflicts the user has since resolved are dropped from the report.
+func (i *Installer) Conflicts() (map[string][]Conflict, error) {
+	all, err := i.loadConflicts()
+	if err != nil {
+		return nil, err
+	}
+
+	open := make(map[string][]Conflict)
+	changed := false
+	for name, conflicts := range all {
+		for _, c := range conflicts {
+			if i.unresolved(name, c) {
+				open[name] = append(open[name], c)
+			} else {
+				changed = true
+			}
+		}
+	}
+	if changed {
+		if err := i.saveConflicts(open); err != nil {
+			return nil, err
+		}
+	}
+	return open, nil
+}
+
+func (i *Installer) unresolved(name string, c Conflict) bool {
+	content, err := os.ReadFile(filepath.Join(i.skillDir(name), filepath.FromSlash(c.File)))
+	if c.Markers {
+		return err == nil && diff.HasConflictMarkers(content)
+	}
+	if err != nil {
+		return c.Hash == ""
+	}
+	return HashBytes(content) == c.Hash
+}
+
+// recordConflicts replaces the conflicts recorded for a skill
+func (i *Installer) recordConflicts(name string, conflicts []Conflict) error {
+	all, err := i.loadConflicts()
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		if _, ok := all[name]; !ok {
+			return nil
+		}
+		delete(all, name)
+	} else {
+		all[name] = conflicts
+	}
+	return i.saveConflicts(all)
+}
+
+func (i *Installer) conflictsPath() string {
+	return filepath.Join(i.baseDir, StateDir, conflictsFileName)
+}
+
+func (i *Installer) loadConflicts() (map[string][]Conflict, error) {
+	all := make(map[string][]Conflict)
+	data, err := os.ReadFile(i.conflictsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return all, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", conflictsFileName, err)
+	}
+	return all, nil
+}
+
+func (i *Installer) saveConflicts(all map[string][]Conflict) error {
+	path := i.conflictsPath()
+	if len(all) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := i.ignoreState(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,103 @@
+package installer
+
+import (
+	"sort"
+)
+
+// SkillUpdate compares an installed skill with what its source offers now
+type SkillUpdate struct {
+	Name     string   `json:"name"`
+	Current  string   `json:"current"`
+	Latest   string   `json:"latest"`
+	Changed  []string `json:"changed"` // Files an update would change
+	Outdated bool     `json:"outdated"`
+}
+
+// CheckUpdate compares the files recorded when a skill was installed with
+// what an update would install. Local edits do not make a skill outdated.
+func (i *Installer) CheckUpdate(name string) (*SkillUpdate, error) {
+	if !i.IsInstalled(name) {
+		return nil, i.notInstalled(name)
+	}
+
+	provider, err := i.UpdateSource(name)
+	if err != nil {
+		return nil, err
+	}
+	skill, err := provider.Find(name)
+	if err != nil {
+		return nil, notFound(name, err)
+	}
+
+	// The index's file hashes avoid downloading the skill, unless an
+	// overlay changes what would be installed
+	entry, tracked := i.ManifestEntry(name)
+	var with []string
+	if tracked {
+		with = entry.With
+	}
+	upstream := withComponents(skill.Hashes, with)
+	if len(upstream) == 0 || i.HasOverlay(name) {
+		files, err := i.fetchFiles(provider, skill, with)
+		if err != nil {
+			return nil, err
+		}
+		upstream = HashFiles(files)
+	}
+
+	installed := make(map[string]string)
+	u := &SkillUpdate{Name: name, Current: "-", Changed: []string{}}
+	if tracked {
+		installed = entry.Files
+		u.Current = versionLabel(entry.Version, entry.Commit)
+	} else {
+		// Untracked: compare with what is on disk
+		local, err := i.ReadSkillFiles(name)
+		if err != nil {
+			return nil, err
+		}
+		installed = HashFiles(local)
+	}
+
+	var commit string
+	if sp, ok := provider.(SourceProvider); ok {
+		commit, _ = sp.ResolveCommit()
+		if commit == "" {
+			commit = sp.GetRef()
+		}
+	}
+	u.Latest = versionLabel(skill.Version, commit)
+	u.Changed = changedFiles(installed, upstream)
+	u.Outdated = len(u.Changed) > 0
+	return u, nil
+}
+
+// versionLabel prefers a skill's declared version, then its short commit
+func versionLabel(version, commit string) string {
+	switch {
+	case version != "":
+		return version
+	case len(commit) >= 40:
+		return commit[:7]
+	case commit != "":
+		return commit
+	}
+	return "-"
+}
+
+// changedFiles lists the paths whose hashes differ between two file sets
+func changedFiles(a, b map[string]string) []string {
+	changed := []string{}
+	for p, hash := range a {
+		if b[p] != hash {
+			changed = append(changed, p)
+		}
+	}
+	for p := range b {
+		if _, ok := a[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,107 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/diff"
+)
+
+// overlayDir holds per-project customisations applied on top of upstream skills
+const overlayDir = "overlays"
+
+// OverlayPath returns the overlay directory for a skill:
+// .vibe-skills/overlays/<skill>/
+func (i *Installer) OverlayPath(name string) string {
+	return filepath.Join(i.baseDir, StateDir, overlayDir, name)
+}
+
+// HasOverlay reports whether the project customises a skill
+func (i *Installer) HasOverlay(name string) bool {
+	info, err := os.Stat(i.OverlayPath(name))
+	return err == nil && info.IsDir()
+}
+
+// applyOverlay applies a skill's overlay to its upstream files. Plain files
+// replace or add the file at the same path; *.patch and *.diff files are
+// unified diffs applied afterwards, in name order.
+func (i *Installer) applyOverlay(name string, files map[string][]byte) (map[string][]byte, error) {
+	if !i.HasOverlay(name) {
+		return files, nil
+	}
+	overlay, err := readTree(i.OverlayPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay: %w", err)
+	}
+
+	out := make(map[string][]byte, len(files))
+	for p, content := range files {
+		out[p] = content
+	}
+
+	var patches []string
+	for p, content := range overlay {
+		if strings.HasPrefix(path.Base(p), ".") {
+			continue
+		}
+		if isPatchFile(p) {
+			patches = append(patches, p)
+			continue
+		}
+		out[p] = content
+	}
+	sort.Strings(patches)
+
+	for _, p := range patches {
+		if err := applyPatch(name, out, overlay[p]); err != nil {
+			return nil, fmt.Errorf("overlay %s: %w", path.Join(name, p), err)
+		}
+	}
+	return out, nil
+}
+
+// applyPatch applies every file section of a unified diff to files
+func applyPatch(skill string, files map[string][]byte, patch []byte) error {
+	filePatches, err := diff.ParsePatch(patch)
+	if err != nil {
+		return err
+	}
+
+	for _, fp := range filePatches {
+		target := overlayTarget(skill, fp.NewName)
+		if fp.NewName == diff.DevNull {
+			target = overlayTarget(skill, fp.OldName)
+		}
+
+		content, ok := files[target]
+		if !ok && fp.OldName != diff.DevNull {
+			return fmt.Errorf("%s: file not in skill", target)
+		}
+
+		patched, err := diff.Apply(content, fp.Hunks)
+		if err != nil {
+			return fmt.Errorf("%s: %w", target, err)
+		}
+		if fp.NewName == diff.DevNull {
+			delete(files, target)
+			continue
+		}
+		files[target] = patched
+	}
+	return nil
+}
+
+// overlayTarget maps a patch file name to a skill file. Diffs from
+// 'vibe-skills status --diff' name files as <skill>/<file>.
+func overlayTarget(skill, name string) string {
+	return strings.TrimPrefix(path.Clean(name), skill+"/")
+}
+
+func isPatchFile(p string) bool {
+	ext := path.Ext(p)
+	return ext == ".patch" || ext == ".diff"
+}
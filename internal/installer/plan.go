@@ -0,0 +1,198 @@
+package installer
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+)
+
+// File operations in a dry-run plan
+const (
+	OpCreate    = "create"
+	OpUpdate    = "update"
+	OpDelete    = "delete"
+	OpUnchanged = "unchanged"
+)
+
+// Plan is what a dry run would have done
+type Plan struct {
+	Skills []*SkillPlan `json:"skills"`
+	Files  []FileOp     `json:"files"` // Files shared by all skills: manifest, skill index, config
+}
+
+// SkillPlan is the file-level plan for installing, updating or removing one skill
+type SkillPlan struct {
+	Skill     string     `json:"skill"`
+	Action    string     `json:"action"` // ActionInstall, ActionUpdate or ActionRemove
+	Files     []FileOp   `json:"files"`
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+}
+
+// FileOp is one file a plan would create, update, delete or leave alone
+type FileOp struct {
+	Op   string `json:"op"`
+	Path string `json:"path"` // Slash-separated, relative to the installer's base directory; directories end in /
+	Size int64  `json:"size"` // Size of the new content, or 0 when not known ahead
+}
+
+// Changes counts the operations that would modify disk
+func (p *SkillPlan) Changes() int {
+	n := 0
+	for _, f := range p.Files {
+		if f.Op != OpUnchanged {
+			n++
+		}
+	}
+	return n
+}
+
+// SetDryRun makes Install, Update and Remove resolve and fetch skills as
+// usual but record a Plan instead of writing anything
+func (i *Installer) SetDryRun(dryRun bool) {
+	i.dryRun = dryRun
+}
+
+// Plan returns what the dry run has collected so far
+func (i *Installer) Plan() *Plan {
+	return &Plan{
+		Skills: append([]*SkillPlan{}, i.plan...),
+		Files:  append([]FileOp{}, i.planFiles...),
+	}
+}
+
+// PlanFile adds a shared file, relative to the base directory, that the
+// dry run would create or update. Files already in the plan are kept once.
+func (i *Installer) PlanFile(rel string) {
+	rel = filepath.ToSlash(rel)
+	for _, f := range i.planFiles {
+		if f.Path == rel {
+			return
+		}
+	}
+	i.planFiles = append(i.planFiles, FileOp{Op: i.writeOp(rel), Path: rel})
+}
+
+// writeOp is the operation writing a file or directory would be
+func (i *Installer) writeOp(rel string) string {
+	if i.planExists(rel) {
+		return OpUpdate
+	}
+	return OpCreate
+}
+
+func (i *Installer) planExists(rel string) bool {
+	_, err := os.Stat(filepath.Join(i.baseDir, filepath.FromSlash(rel)))
+	return err == nil
+}
+
+// recordPlan adds a plan that turns the installed skill's files into files
+// (nil to remove the skill), with the rest of what that writes: agent target
+// files, the merge base (kept as base, if rewritten), the manifest and the
+// skill index
+func (i *Installer) recordPlan(action, name string, files, base map[string][]byte, conflicts []Conflict, targets []Target) error {
+	local := map[string][]byte{}
+	if i.IsInstalled(name) {
+		var err error
+		if local, err = i.ReadSkillFiles(name); err != nil {
+			return err
+		}
+	}
+
+	paths := make(map[string]bool)
+	for p := range local {
+		paths[p] = true
+	}
+	for p := range files {
+		paths[p] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	sp := &SkillPlan{Skill: name, Action: action, Files: []FileOp{}, Conflicts: conflicts}
+	for _, p := range sorted {
+		before, existed := local[p]
+		after, wanted := files[p]
+		op := FileOp{Path: path.Join(TargetDir, name, p), Size: int64(len(after))}
+		switch {
+		case !wanted:
+			op.Op, op.Size = OpDelete, int64(len(before))
+		case !existed:
+			op.Op = OpCreate
+		case bytes.Equal(before, after):
+			op.Op = OpUnchanged
+		default:
+			op.Op = OpUpdate
+		}
+		sp.Files = append(sp.Files, op)
+	}
+	removing := files == nil
+
+	// Agent targets: written for the selected ones, removed from those the
+	// skill was written to before but are no longer selected
+	var previous []string
+	if entry, ok := i.ManifestEntry(name); ok {
+		previous = entry.Written
+	}
+	for _, t := range targets {
+		if p := t.Path(name); p != "" {
+			sp.Files = append(sp.Files, FileOp{Op: i.writeOp(p), Path: p})
+		}
+	}
+	for _, prev := range previous {
+		if slices.ContainsFunc(targets, func(t Target) bool { return t.Name() == prev }) {
+			continue
+		}
+		t, err := NewTarget(prev, i.baseDir)
+		if err != nil {
+			return err
+		}
+		if p := t.Path(name); p != "" && i.planExists(p) {
+			op := OpDelete
+			if t.Name() == TargetCodex {
+				op = OpUpdate // Only the skill's section of AGENTS.md goes
+			}
+			sp.Files = append(sp.Files, FileOp{Op: op, Path: p})
+		}
+	}
+
+	// Store-managed skills keep no merge base
+	basePath := path.Join(StateDir, baseCopyDir, name) + "/"
+	switch {
+	case removing && i.planExists(basePath):
+		sp.Files = append(sp.Files, FileOp{Op: OpDelete, Path: basePath})
+	case base != nil && i.opts.Store == nil:
+		op := FileOp{Op: OpCreate, Path: basePath}
+		if old, ok := i.readBase(name); ok {
+			op.Op = OpUpdate
+			if maps.EqualFunc(old, base, bytes.Equal) {
+				op.Op = OpUnchanged
+			}
+		}
+		sp.Files = append(sp.Files, op)
+		if ignore := path.Join(StateDir, stateIgnoreFile); !i.planExists(ignore) {
+			i.PlanFile(ignore)
+		}
+	}
+	i.plan = append(i.plan, sp)
+
+	shared := []string{path.Join(TargetDir, ManifestFileName)}
+	if i.opts.IndexFile != "" {
+		if rel, err := filepath.Rel(i.baseDir, i.opts.IndexFile); err == nil {
+			shared = append(shared, rel)
+		}
+	}
+	for _, rel := range shared {
+		// Removing a skill edits them, if they exist at all
+		if !removing || i.planExists(filepath.ToSlash(rel)) {
+			i.PlanFile(rel)
+		}
+	}
+	return nil
+}
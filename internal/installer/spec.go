@@ -0,0 +1,109 @@
+package installer
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Spec is a declared skill with the per-skill settings of its config entry
+type Spec struct {
+	Name     string        // Skill name or stack/name
+	Provider SkillProvider // Where to fetch from; the installer's provider when nil
+	Version  string        // Skill version the provider must offer, when set
+	Targets  []string      // Agent formats for this skill instead of the configured ones
+	With     []string      // Optional components to install; all when empty
+}
+
+// InstallSpecs installs declared skills with their per-skill settings
+func (i *Installer) InstallSpecs(specs []Spec) (installed []string, errors []error) {
+	reason := i.reason
+	if reason == "" {
+		reason = ReasonExplicit
+	}
+	for _, spec := range specs {
+		if err := i.install(spec, reason); err != nil {
+			errors = append(errors, fmt.Errorf("%s: %w", spec.Name, err))
+		} else {
+			installed = append(installed, spec.Name)
+		}
+	}
+	return
+}
+
+// providerOf returns the provider a spec fetches from
+func (i *Installer) providerOf(spec Spec) SkillProvider {
+	if spec.Provider != nil {
+		return spec.Provider
+	}
+	return i.provider
+}
+
+// specDrift describes how an installed skill's recorded settings differ
+// from its spec, or returns "" when they match
+func specDrift(spec Spec, entry *ManifestEntry) string {
+	var drift []string
+	if spec.Provider != nil {
+		ref := providerRef(spec.Provider)
+		switch {
+		case ref != "" && ref != entry.Ref:
+			drift = append(drift, fmt.Sprintf("ref %s → %s", orDash(entry.Ref), ref))
+		case commitRef.MatchString(ref) && entry.Commit != "" && !strings.HasPrefix(entry.Commit, ref):
+			// Pinned to a commit, but the manifest records another one
+			drift = append(drift, fmt.Sprintf("commit %s → %s", entry.Commit, ref))
+		}
+		if sp, ok := spec.Provider.(SourceProvider); ok && entry.Source != "" && sp.Source() != entry.Source {
+			drift = append(drift, fmt.Sprintf("source %s → %s", entry.Source, sp.Source()))
+		}
+	}
+	if spec.Version != "" && spec.Version != entry.Version {
+		drift = append(drift, fmt.Sprintf("version %s → %s", orDash(entry.Version), spec.Version))
+	}
+	if !slices.Equal(spec.Targets, entry.Targets) {
+		drift = append(drift, "targets changed")
+	}
+	if !slices.Equal(spec.With, entry.With) {
+		drift = append(drift, "components changed")
+	}
+	return strings.Join(drift, ", ")
+}
+
+// commitRef matches refs that are (abbreviated) commit SHAs
+var commitRef = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// providerLabel names a provider for messages
+func providerLabel(provider SkillProvider) string {
+	if sp, ok := provider.(SourceProvider); ok {
+		return sp.Source() + "@" + sp.GetRef()
+	}
+	return "the registry"
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// withComponents keeps SKILL.md, top-level files and the listed top-level
+// directories of a skill. Without components everything is kept.
+func withComponents[V any](files map[string]V, with []string) map[string]V {
+	if len(with) == 0 {
+		return files
+	}
+	dirs := make(map[string]bool)
+	for _, w := range with {
+		dirs[path.Clean(w)] = true
+	}
+	kept := make(map[string]V)
+	for p, v := range files {
+		dir, _, nested := strings.Cut(p, "/")
+		if !nested || dirs[dir] {
+			kept[p] = v
+		}
+	}
+	return kept
+}
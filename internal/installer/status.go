@@ -0,0 +1,171 @@
+package installer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Skill states reported by Status
+const (
+	StateClean     = "clean"
+	StateModified  = "modified"  // Files differ from what was installed
+	StateLinked    = "linked"    // Linked from a local directory, not tracked
+	StateUntracked = "untracked" // Installed without a manifest entry
+)
+
+// SkillStatus describes local changes to an installed skill
+type SkillStatus struct {
+	Name     string   `json:"name"`
+	State    string   `json:"state"`
+	Modified []string `json:"modified,omitempty"`
+	Added    []string `json:"added,omitempty"`
+	Deleted  []string `json:"deleted,omitempty"`
+}
+
+// Drifted reports whether the skill differs from what was installed
+func (s *SkillStatus) Drifted() bool {
+	return len(s.Modified)+len(s.Added)+len(s.Deleted) > 0
+}
+
+// Status compares an installed skill against the file hashes recorded in the
+// manifest, or against its store entry when it is store-managed
+func (i *Installer) Status(name string) (*SkillStatus, error) {
+	if !i.IsInstalled(name) {
+		return nil, i.notInstalled(name)
+	}
+
+	status := &SkillStatus{Name: name, State: StateClean}
+	if i.IsLinked(name) {
+		status.State = StateLinked
+		return status, nil
+	}
+
+	entry, ok := i.ManifestEntry(name)
+	if !ok {
+		status.State = StateUntracked
+		return status, nil
+	}
+
+	local, err := i.ReadSkillFiles(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Another project's update moves a shared store channel, which is not a
+	// local edit: compare with the store entry the skill points to instead
+	expected := entry.Files
+	if stored, ok := i.storedFiles(name); ok {
+		expected = HashFiles(stored)
+	}
+
+	for path, hash := range expected {
+		content, ok := local[path]
+		switch {
+		case !ok:
+			status.Deleted = append(status.Deleted, path)
+		case HashBytes(content) != hash:
+			status.Modified = append(status.Modified, path)
+		}
+	}
+	for path := range local {
+		if _, ok := expected[path]; !ok {
+			status.Added = append(status.Added, path)
+		}
+	}
+
+	sort.Strings(status.Modified)
+	sort.Strings(status.Added)
+	sort.Strings(status.Deleted)
+	if status.Drifted() {
+		status.State = StateModified
+	}
+	return status, nil
+}
+
+// StatusAll reports the status of every installed skill
+func (i *Installer) StatusAll() ([]*SkillStatus, error) {
+	installed, err := i.ListInstalled()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []*SkillStatus
+	for _, name := range installed {
+		s, err := i.Status(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// ReadSkillFiles reads an installed skill's files, keyed by slash-separated
+// relative path. Installer bookkeeping files are skipped.
+func (i *Installer) ReadSkillFiles(name string) (map[string][]byte, error) {
+	dir := i.skillDir(name)
+	// Resolve links so a store or linked install is walked through
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+	return readTree(dir)
+}
+
+// readTree reads every file under dir except installer bookkeeping files
+func readTree(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || isBookkeeping(d.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	return files, err
+}
+
+// FetchInstalledVersion returns the files of a skill as they were when it was
+// installed: the kept base copy, or a fetch of the recorded commit
+func (i *Installer) FetchInstalledVersion(name string) (map[string][]byte, error) {
+	if base, ok := i.readBase(name); ok {
+		return base, nil
+	}
+
+	entry, ok := i.ManifestEntry(name)
+	if !ok {
+		return nil, fmt.Errorf("no install record for %s", name)
+	}
+	if i.opts.ProviderFor == nil || entry.Source == "" {
+		return nil, fmt.Errorf("no source recorded for %s", name)
+	}
+
+	pinned := *entry
+	if pinned.Commit != "" {
+		pinned.Ref = pinned.Commit
+	}
+	provider, err := i.opts.ProviderFor(&pinned)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.Fetch(provider, name)
+}
+
+func isBookkeeping(name string) bool {
+	return name == linkMarker || name == storeMarker || strings.HasPrefix(name, ".vibe-skills")
+}
@@ -0,0 +1,358 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// storeMarker is written into copied installs to record their store entry
+	storeMarker = ".vibe-skills-store"
+
+	storeProjectsFile = "projects.json"
+	channelPrefix     = "@"
+	hashLength        = 16
+)
+
+// Store keeps one copy of each skill version, shared between projects.
+//
+// Layout:
+//
+//	<dir>/<skill>/<hash>/...       content, keyed by a hash of all files
+//	<dir>/<skill>/@<src>-<ref> -> <hash>
+//	                               channel, moved on install/update from <ref>
+//	                               of the registry hashed as <src>
+//	<dir>/projects.json            skills directories that link into the store
+//
+// Projects symlink .claude/skills/<skill> to a channel, so an update from any
+// project shows up in every project tracking the same registry and ref. Where
+// symlinks are unavailable the files are copied from the hash directory.
+type Store struct {
+	dir string
+}
+
+// DefaultStoreDir returns ~/.vibe-skills/store
+func DefaultStoreDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, StateDir, "store")
+}
+
+// NewStore creates a store rooted at dir, or the default location if empty
+func NewStore(dir string) *Store {
+	if dir == "" {
+		dir = DefaultStoreDir()
+	}
+	return &Store{dir: dir}
+}
+
+// Dir returns the store root
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Contains reports whether a path points into the store
+func (s *Store) Contains(path string) bool {
+	rel, err := filepath.Rel(s.dir, path)
+	return err == nil && rel != "." && !strings.HasPrefix(rel, "..")
+}
+
+// Put stores a skill's files, returning the content-addressed entry path.
+// Existing entries are reused without rewriting.
+func (s *Store) Put(name string, files map[string][]byte) (string, error) {
+	entry := filepath.Join(s.dir, name, hashFiles(files))
+	if _, err := os.Stat(entry); err == nil {
+		return entry, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(entry), 0755); err != nil {
+		return "", err
+	}
+	tmp, err := os.MkdirTemp(filepath.Dir(entry), ".tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	for relPath, content := range files {
+		fullPath := filepath.Join(tmp, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(fullPath, content, 0644); err != nil {
+			return "", err
+		}
+	}
+
+	if err := os.Rename(tmp, entry); err != nil {
+		// Another process may have stored the same content concurrently
+		if _, statErr := os.Stat(entry); statErr == nil {
+			return entry, nil
+		}
+		return "", err
+	}
+	return entry, nil
+}
+
+// Channel points <skill>/@<src>-<ref> at a stored entry and returns the
+// channel path. The source is part of the name because registries from other
+// repos share ref names like "main".
+func (s *Store) Channel(name, source, ref, entry string) (string, error) {
+	sum := sha256.Sum256([]byte(source))
+	channel := filepath.Join(s.dir, name, channelPrefix+hex.EncodeToString(sum[:6])+"-"+sanitizeRef(ref))
+	tmp := channel + ".tmp"
+	_ = os.Remove(tmp)
+	if err := os.Symlink(filepath.Base(entry), tmp); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, channel); err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+	return channel, nil
+}
+
+// LinkInto makes target a link to a stored skill: a symlink to the ref channel
+// where possible, otherwise copies of the entry's files
+func (s *Store) LinkInto(name, source, ref, entry, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	if channel, err := s.Channel(name, source, ref, entry); err == nil {
+		if err := os.Symlink(channel, target); err == nil {
+			return nil
+		}
+	}
+
+	err := filepath.WalkDir(entry, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(entry, p)
+		if err != nil {
+			return err
+		}
+		out := filepath.Join(target, rel)
+		if d.IsDir() {
+			return os.MkdirAll(out, 0755)
+		}
+		// Copies, not hardlinks: a hardlinked file shares the store entry's
+		// inode, so a local edit would change it for every project
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(out, data, 0644)
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(target, storeMarker), []byte(entry+"\n"), 0644)
+}
+
+// Register records a project skills directory so prune can see its references
+func (s *Store) Register(skillsDir string) error {
+	abs, err := filepath.Abs(skillsDir)
+	if err != nil {
+		return err
+	}
+
+	projects, err := s.projects()
+	if err != nil {
+		return err
+	}
+	for _, p := range projects {
+		if p == abs {
+			return nil
+		}
+	}
+	return s.saveProjects(append(projects, abs))
+}
+
+// PruneResult lists what a prune removed (or would remove)
+type PruneResult struct {
+	Removed  []string // Store entries and channels no project references
+	Projects int      // Projects still referencing the store
+}
+
+// Prune removes entries and channels that no registered project references.
+// With dryRun set nothing is deleted.
+func (s *Store) Prune(dryRun bool) (*PruneResult, error) {
+	projects, err := s.projects()
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool)
+	var live []string
+	for _, project := range projects {
+		entries, err := os.ReadDir(project)
+		if err != nil {
+			continue // Project deleted or moved
+		}
+		used := false
+		for _, e := range entries {
+			for _, ref := range s.references(filepath.Join(project, e.Name())) {
+				referenced[ref] = true
+				used = true
+			}
+		}
+		if used {
+			live = append(live, project)
+		}
+	}
+
+	result := &PruneResult{Projects: len(live)}
+
+	skills, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+		return nil, err
+	}
+	for _, skill := range skills {
+		if !skill.IsDir() {
+			continue
+		}
+		skillDir := filepath.Join(s.dir, skill.Name())
+		entries, err := os.ReadDir(skillDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			p := filepath.Join(skillDir, e.Name())
+			if !referenced[p] {
+				result.Removed = append(result.Removed, p)
+			}
+		}
+	}
+	sort.Strings(result.Removed)
+
+	if dryRun {
+		return result, nil
+	}
+
+	for _, p := range result.Removed {
+		if err := os.RemoveAll(p); err != nil {
+			return nil, err
+		}
+		// Drop skill directories left empty
+		_ = os.Remove(filepath.Dir(p))
+	}
+	if err := s.saveProjects(live); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// references returns the store paths (channel and entry) a project skill uses
+func (s *Store) references(skillPath string) []string {
+	fi, err := os.Lstat(skillPath)
+	if err != nil {
+		return nil
+	}
+
+	if fi.Mode()&os.ModeSymlink != 0 {
+		channel, err := os.Readlink(skillPath)
+		if err != nil || !s.Contains(channel) {
+			return nil
+		}
+		// Channels sit next to the entries of the same skill, whatever their
+		// registry, so prune sees both as siblings under <skill>/
+		refs := []string{channel}
+		if target, err := os.Readlink(channel); err == nil {
+			refs = append(refs, filepath.Join(filepath.Dir(channel), target))
+		} else {
+			refs = append(refs, channel) // Linked straight to an entry
+		}
+		return refs
+	}
+
+	data, err := os.ReadFile(filepath.Join(skillPath, storeMarker))
+	if err != nil {
+		return nil
+	}
+	entry := strings.TrimSpace(string(data))
+	if !s.Contains(entry) {
+		return nil
+	}
+	return []string{entry}
+}
+
+func (s *Store) projects() ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, storeProjectsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var file struct {
+		Projects []string `json:"projects"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", storeProjectsFile, err)
+	}
+	return file.Projects, nil
+}
+
+func (s *Store) saveProjects(projects []string) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return err
+	}
+	sort.Strings(projects)
+	data, err := json.MarshalIndent(map[string][]string{"projects": projects}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dir, storeProjectsFile), data, 0644)
+}
+
+// storedFiles returns the files of the store entry a store-managed skill
+// points to, unless the entry itself was edited (its content no longer
+// matches its hash)
+func (i *Installer) storedFiles(name string) (map[string][]byte, bool) {
+	refs := i.store().references(i.skillDir(name))
+	if len(refs) == 0 {
+		return nil, false
+	}
+	entry := refs[len(refs)-1]
+	files, err := readTree(entry)
+	if err != nil || hashFiles(files) != filepath.Base(entry) {
+		return nil, false
+	}
+	return files, true
+}
+
+// hashFiles returns a stable content hash over all file paths and contents
+func hashFiles(files map[string][]byte) string {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, p := range paths {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+		h.Write(files[p])
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:hashLength]
+}
+
+func sanitizeRef(ref string) string {
+	if ref == "" {
+		return "default"
+	}
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(ref)
+}
@@ -0,0 +1,125 @@
+package installer
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Sync action kinds
+const (
+	ActionInstall = "install"
+	ActionUpdate  = "update"
+	ActionRemove  = "remove"
+)
+
+// Action is one step of a sync plan
+type Action struct {
+	Kind   string `json:"action"`
+	Skill  string `json:"skill"`
+	Detail string `json:"detail,omitempty"`
+	Spec   *Spec  `json:"-"` // Declared settings for installs and updates
+}
+
+// SyncPlan lists what Sync would do to make the skills directory match a
+// declared skill list
+type SyncPlan struct {
+	Actions   []Action `json:"actions"`
+	Unchanged []string `json:"unchanged"`
+	Extra     []string `json:"extra"` // Installed but not declared, kept without prune
+}
+
+// Empty reports whether the plan has nothing to do
+func (p *SyncPlan) Empty() bool {
+	return len(p.Actions) == 0
+}
+
+// PlanSync compares the declared skills with what is installed. Skills whose
+// recorded source, ref or settings differ from their spec are updated. With
+// prune, skills that are not declared are removed; linked skills are never
+// touched.
+func (i *Installer) PlanSync(declared []Spec, prune bool) (*SyncPlan, error) {
+	plan := &SyncPlan{Actions: []Action{}, Unchanged: []string{}, Extra: []string{}}
+
+	wanted := make(map[string]bool)
+	for _, spec := range declared {
+		spec := spec
+		skill, err := i.providerOf(spec).Find(spec.Name)
+		if err != nil {
+			return nil, fmt.Errorf("declared %w", notFound(spec.Name, err))
+		}
+		if wanted[skill.Name] {
+			continue
+		}
+		wanted[skill.Name] = true
+
+		entry, tracked := i.ManifestEntry(skill.Name)
+		switch {
+		case !i.IsInstalled(skill.Name):
+			plan.Actions = append(plan.Actions, Action{Kind: ActionInstall, Skill: skill.Name, Spec: &spec})
+		case i.IsLinked(skill.Name):
+			plan.Unchanged = append(plan.Unchanged, skill.Name)
+		case tracked && specDrift(spec, entry) != "":
+			plan.Actions = append(plan.Actions, Action{Kind: ActionUpdate, Skill: skill.Name, Detail: specDrift(spec, entry), Spec: &spec})
+		default:
+			u, err := i.CheckUpdate(skill.Name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", skill.Name, err)
+			}
+			if !u.Outdated {
+				plan.Unchanged = append(plan.Unchanged, skill.Name)
+				continue
+			}
+			detail := fmt.Sprintf("%d file(s) changed", len(u.Changed))
+			if u.Current != u.Latest {
+				detail = fmt.Sprintf("%s → %s, %s", u.Current, u.Latest, detail)
+			}
+			plan.Actions = append(plan.Actions, Action{Kind: ActionUpdate, Skill: skill.Name, Detail: detail})
+		}
+	}
+
+	installed, err := i.ListInstalled()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(installed)
+	for _, name := range installed {
+		if wanted[name] {
+			continue
+		}
+		if !prune || i.IsLinked(name) {
+			plan.Extra = append(plan.Extra, name)
+			continue
+		}
+		plan.Actions = append(plan.Actions, Action{Kind: ActionRemove, Skill: name, Detail: "not declared"})
+	}
+
+	return plan, nil
+}
+
+// ApplySync carries out a sync plan. Installs are recorded as coming from
+// the config.
+func (i *Installer) ApplySync(plan *SyncPlan) (done []Action, errors []error) {
+	for _, a := range plan.Actions {
+		var err error
+		switch a.Kind {
+		case ActionInstall:
+			spec := Spec{Name: a.Skill}
+			if a.Spec != nil {
+				spec = *a.Spec
+			}
+			err = i.install(spec, ReasonConfig)
+		case ActionUpdate:
+			_, err = i.updateSkill(a.Skill, a.Spec)
+		case ActionRemove:
+			err = i.Remove(a.Skill)
+		default:
+			err = fmt.Errorf("unknown action %q", a.Kind)
+		}
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%s %s: %w", a.Kind, a.Skill, err))
+			continue
+		}
+		done = append(done, a)
+	}
+	return
+}
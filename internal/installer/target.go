@@ -0,0 +1,276 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cuongtl1992/vibe-skills/internal/frontmatter"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"gopkg.in/yaml.v3"
+)
+
+// Target names accepted by --target and the 'targets' config key
+const (
+	TargetClaude  = "claude"
+	TargetCursor  = "cursor"
+	TargetCopilot = "copilot"
+	TargetCodex   = "codex"
+)
+
+// TargetNames lists every supported target
+var TargetNames = []string{TargetClaude, TargetCursor, TargetCopilot, TargetCodex}
+
+// Target writes a skill in another coding agent's format. The .claude/skills
+// copy is always installed and is the source of truth the other targets are
+// generated from; their files link back to it for reference material.
+type Target interface {
+	// Name returns the target name, e.g. "cursor"
+	Name() string
+
+	// Path returns the file the target keeps a skill in, slash-separated and
+	// relative to the base directory, or "" when it writes none
+	Path(name string) string
+
+	// Sync writes or refreshes the target's copy of a skill
+	Sync(skill *registry.Skill, files map[string][]byte) error
+
+	// Remove deletes the target's copy of a skill, if any
+	Remove(name string) error
+}
+
+// NewTarget creates the adapter for a target name
+func NewTarget(name, baseDir string) (Target, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case TargetClaude:
+		return claudeTarget{}, nil
+	case TargetCursor:
+		return &cursorTarget{baseDir: baseDir}, nil
+	case TargetCopilot:
+		return &copilotTarget{baseDir: baseDir}, nil
+	case TargetCodex, "agents":
+		return &codexTarget{baseDir: baseDir}, nil
+	default:
+		return nil, fmt.Errorf("unknown target %q (available: %s)", name, strings.Join(TargetNames, ", "))
+	}
+}
+
+// NewTargets creates adapters for a list of target names, skipping duplicates
+func NewTargets(names []string, baseDir string) ([]Target, error) {
+	var targets []Target
+	seen := make(map[string]bool)
+	for _, name := range names {
+		t, err := NewTarget(name, baseDir)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[t.Name()] {
+			seen[t.Name()] = true
+			targets = append(targets, t)
+		}
+	}
+	return targets, nil
+}
+
+// claudeTarget is the canonical .claude/skills copy written by the installer itself
+type claudeTarget struct{}
+
+func (claudeTarget) Name() string                                  { return TargetClaude }
+func (claudeTarget) Path(string) string                            { return "" }
+func (claudeTarget) Sync(*registry.Skill, map[string][]byte) error { return nil }
+func (claudeTarget) Remove(string) error                           { return nil }
+
+// cursorTarget writes .cursor/rules/<name>.mdc
+type cursorTarget struct {
+	baseDir string
+}
+
+func (t *cursorTarget) Name() string { return TargetCursor }
+
+func (t *cursorTarget) Path(name string) string { return ".cursor/rules/" + name + ".mdc" }
+
+func (t *cursorTarget) Sync(skill *registry.Skill, files map[string][]byte) error {
+	const dir = ".cursor/rules"
+	fm, body := parseSkill(skill, files)
+
+	header := []yamlField{{"description", fm.Description}}
+	if len(fm.Globs) > 0 {
+		header = append(header, yamlField{"globs", strings.Join(fm.Globs, ",")})
+	}
+	header = append(header, yamlField{"alwaysApply", false})
+
+	content := renderFrontmatter(header) + generatedNotice(skill.Name) +
+		rewriteLinks(body, dir, skill.Name) + referenceList(files, dir, skill.Name)
+	return writeFile(filepath.Join(t.baseDir, filepath.FromSlash(t.Path(skill.Name))), content)
+}
+
+func (t *cursorTarget) Remove(name string) error {
+	return removeFile(filepath.Join(t.baseDir, filepath.FromSlash(t.Path(name))))
+}
+
+// copilotTarget writes .github/instructions/<name>.instructions.md
+type copilotTarget struct {
+	baseDir string
+}
+
+func (t *copilotTarget) Name() string { return TargetCopilot }
+
+func (t *copilotTarget) Path(name string) string {
+	return ".github/instructions/" + name + ".instructions.md"
+}
+
+func (t *copilotTarget) Sync(skill *registry.Skill, files map[string][]byte) error {
+	const dir = ".github/instructions"
+	fm, body := parseSkill(skill, files)
+
+	applyTo := "**"
+	if len(fm.Globs) > 0 {
+		applyTo = strings.Join(fm.Globs, ",")
+	}
+	header := []yamlField{{"description", fm.Description}, {"applyTo", applyTo}}
+
+	content := renderFrontmatter(header) + generatedNotice(skill.Name) +
+		rewriteLinks(body, dir, skill.Name) + referenceList(files, dir, skill.Name)
+	return writeFile(filepath.Join(t.baseDir, filepath.FromSlash(t.Path(skill.Name))), content)
+}
+
+func (t *copilotTarget) Remove(name string) error {
+	return removeFile(filepath.Join(t.baseDir, filepath.FromSlash(t.Path(name))))
+}
+
+// codexTarget keeps one managed section per skill in AGENTS.md
+type codexTarget struct {
+	baseDir string
+}
+
+const agentsFile = "AGENTS.md"
+
+func (t *codexTarget) Name() string { return TargetCodex }
+
+func (t *codexTarget) Path(string) string { return agentsFile }
+
+func (t *codexTarget) Sync(skill *registry.Skill, files map[string][]byte) error {
+	fm, _ := parseSkill(skill, files)
+	begin, end := agentsMarkers(skill.Name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Skill: %s\n\n", skill.Name)
+	if fm.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", fm.Description)
+	}
+	fmt.Fprintf(&b, "When this applies, read and follow `%s/%s/SKILL.md`.\n", TargetDir, skill.Name)
+
+	return updateFile(filepath.Join(t.baseDir, agentsFile), func(content string) (string, error) {
+		return upsertBlock(content, begin, end, b.String())
+	})
+}
+
+func (t *codexTarget) Remove(name string) error {
+	begin, end := agentsMarkers(name)
+	return updateFile(filepath.Join(t.baseDir, agentsFile), func(content string) (string, error) {
+		return removeBlock(content, begin, end)
+	})
+}
+
+func agentsMarkers(name string) (string, string) {
+	return fmt.Sprintf("<!-- vibe-skills:begin %s -->", name), fmt.Sprintf("<!-- vibe-skills:end %s -->", name)
+}
+
+// parseSkill returns the SKILL.md frontmatter (falling back to registry data) and body
+func parseSkill(skill *registry.Skill, files map[string][]byte) (*frontmatter.Frontmatter, string) {
+	content := files["SKILL.md"]
+	fm, body, err := frontmatter.Parse(content)
+	if err != nil || fm == nil {
+		fm, body = &frontmatter.Frontmatter{}, content
+	}
+	if fm.Description == "" {
+		fm.Description = skill.Description
+	}
+	return fm, strings.TrimLeft(string(body), "\n")
+}
+
+type yamlField struct {
+	key   string
+	value interface{}
+}
+
+func renderFrontmatter(fields []yamlField) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	for _, f := range fields {
+		data, err := yaml.Marshal(f.value)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s: %s", f.key, data)
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
+
+func generatedNotice(name string) string {
+	return fmt.Sprintf("<!-- Generated by vibe-skills from %s/%s. Edits are overwritten on update. -->\n\n", TargetDir, name)
+}
+
+var mdLink = regexp.MustCompile(`(\]\()([^)\s#]+)([^)]*\))`)
+
+// rewriteLinks points relative links in a skill at its .claude/skills copy,
+// as seen from the directory the target file lives in
+func rewriteLinks(body, fromDir, name string) string {
+	return mdLink.ReplaceAllStringFunc(body, func(m string) string {
+		parts := mdLink.FindStringSubmatch(m)
+		target := parts[2]
+		if isAbsoluteLink(target) {
+			return m
+		}
+		return parts[1] + skillRelPath(fromDir, name, target) + parts[3]
+	})
+}
+
+// referenceList lists the skill's extra files so the agent can open them
+func referenceList(files map[string][]byte, fromDir, name string) string {
+	var extra []string
+	for p := range files {
+		if p != "SKILL.md" {
+			extra = append(extra, p)
+		}
+	}
+	if len(extra) == 0 {
+		return ""
+	}
+	sort.Strings(extra)
+
+	var b strings.Builder
+	b.WriteString("\n## Skill Files\n\n")
+	for _, p := range extra {
+		fmt.Fprintf(&b, "- [%s](%s)\n", p, skillRelPath(fromDir, name, p))
+	}
+	return b.String()
+}
+
+func skillRelPath(fromDir, name, file string) string {
+	depth := strings.Count(path.Clean(fromDir), "/") + 1
+	return strings.Repeat("../", depth) + path.Join(TargetDir, name, file)
+}
+
+func isAbsoluteLink(target string) bool {
+	return strings.Contains(target, "://") || strings.HasPrefix(target, "/") || strings.HasPrefix(target, "mailto:")
+}
+
+func writeFile(p, content string) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(p, []byte(content), 0644)
+}
+
+func removeFile(p string) error {
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
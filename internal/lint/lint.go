@@ -0,0 +1,399 @@
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cuongtl1992/vibe-skills/internal/frontmatter"
+)
+
+const (
+	SkillFile = "SKILL.md"
+
+	// MaxDescriptionLength is the longest description Claude Code accepts
+	MaxDescriptionLength = 1024
+	// MinDescriptionLength is the shortest description considered useful for triggering
+	MinDescriptionLength = 40
+
+	DefaultMaxFileSize   = 256 * 1024
+	DefaultMaxSkillLines = 500
+)
+
+// Severity indicates how serious an issue is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is a single problem found in a skill
+type Issue struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+}
+
+// String formats the issue as file:line: severity: message [rule]
+func (i Issue) String() string {
+	loc := i.File
+	if i.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", i.File, i.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", loc, i.Severity, i.Message, i.Rule)
+}
+
+// Options configures the linter
+type Options struct {
+	MaxFileSize   int64 // Files larger than this are flagged (bytes)
+	MaxSkillLines int   // SKILL.md longer than this is flagged
+}
+
+// Result holds the issues found for a set of skills
+type Result struct {
+	Skills []string `json:"skills"`
+	Issues []Issue  `json:"issues"`
+}
+
+// Errors returns the number of error-level issues
+func (r *Result) Errors() int {
+	return r.count(SeverityError)
+}
+
+// Warnings returns the number of warning-level issues
+func (r *Result) Warnings() int {
+	return r.count(SeverityWarning)
+}
+
+func (r *Result) count(sev Severity) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == sev {
+			n++
+		}
+	}
+	return n
+}
+
+// Linter validates skill directories
+type Linter struct {
+	opts *Options
+}
+
+// New creates a linter, filling in defaults for unset options
+func New(opts *Options) *Linter {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxFileSize == 0 {
+		o.MaxFileSize = DefaultMaxFileSize
+	}
+	if o.MaxSkillLines == 0 {
+		o.MaxSkillLines = DefaultMaxSkillLines
+	}
+	return &Linter{opts: &o}
+}
+
+// Lint validates a single skill directory or every skill below a tree
+func (l *Linter) Lint(root string) (*Result, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", root)
+	}
+
+	var dirs []string
+	if _, err := os.Stat(filepath.Join(root, SkillFile)); err == nil {
+		dirs = append(dirs, root)
+	} else {
+		err := filepath.WalkDir(root, func(p string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() && p != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if !d.IsDir() && d.Name() == SkillFile {
+				dirs = append(dirs, filepath.Dir(p))
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no %s found under %s", SkillFile, root)
+	}
+
+	result := &Result{Skills: []string{}, Issues: []Issue{}}
+	for _, dir := range dirs {
+		issues, err := l.LintSkill(dir)
+		if err != nil {
+			return nil, err
+		}
+		result.Skills = append(result.Skills, dir)
+		result.Issues = append(result.Issues, issues...)
+	}
+	return result, nil
+}
+
+// LintSkill validates one skill directory
+func (l *Linter) LintSkill(dir string) ([]Issue, error) {
+	files, err := listFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checker{dir: dir, opts: l.opts, referenced: make(map[string]bool)}
+
+	contents := make(map[string][]byte)
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, err
+		}
+		contents[rel] = data
+		c.checkFile(rel, data)
+	}
+
+	c.checkFrontmatter(contents[SkillFile])
+
+	for _, rel := range files {
+		if strings.EqualFold(path.Ext(rel), ".md") && utf8.Valid(contents[rel]) {
+			c.checkLinks(rel, contents[rel], files)
+		}
+	}
+	c.checkUnreferenced(files, contents)
+
+	sort.SliceStable(c.issues, func(i, j int) bool {
+		if c.issues[i].File != c.issues[j].File {
+			return c.issues[i].File < c.issues[j].File
+		}
+		return c.issues[i].Line < c.issues[j].Line
+	})
+	return c.issues, nil
+}
+
+type checker struct {
+	dir        string
+	opts       *Options
+	issues     []Issue
+	referenced map[string]bool
+}
+
+func (c *checker) add(rel string, line int, sev Severity, rule, format string, args ...interface{}) {
+	c.issues = append(c.issues, Issue{
+		File:     filepath.ToSlash(filepath.Join(c.dir, filepath.FromSlash(rel))),
+		Line:     line,
+		Severity: sev,
+		Rule:     rule,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) checkFile(rel string, data []byte) {
+	if strings.HasPrefix(path.Base(rel), ".") {
+		c.add(rel, 0, SeverityWarning, "registry-excluded", "hidden file is excluded from the registry and will not be installed")
+	}
+	if int64(len(data)) > c.opts.MaxFileSize {
+		c.add(rel, 0, SeverityWarning, "file-size", "file is %s, larger than the %s limit", formatSize(int64(len(data))), formatSize(c.opts.MaxFileSize))
+	}
+	if !utf8.Valid(data) {
+		c.add(rel, invalidUTF8Line(data), SeverityError, "utf8", "file is not valid UTF-8")
+	}
+}
+
+var triggerWords = []string{"use when", "use for", "use this", "when ", "whenever"}
+
+func (c *checker) checkFrontmatter(content []byte) {
+	fm, _, err := frontmatter.Parse(content)
+	if err != nil {
+		c.add(SkillFile, 1, SeverityError, "frontmatter", "%v", err)
+		return
+	}
+	if fm == nil {
+		c.add(SkillFile, 1, SeverityError, "frontmatter", "missing YAML frontmatter with name and description")
+		return
+	}
+
+	folder := filepath.Base(c.dir)
+	switch {
+	case !fm.Has("name") || strings.TrimSpace(fm.Name) == "":
+		c.add(SkillFile, 1, SeverityError, "frontmatter-name", "frontmatter is missing required field 'name'")
+	case fm.Name != folder:
+		c.add(SkillFile, fm.Lines["name"], SeverityError, "frontmatter-name", "name %q does not match folder %q", fm.Name, folder)
+	}
+
+	desc := strings.TrimSpace(fm.Description)
+	line := fm.Lines["description"]
+	switch {
+	case !fm.Has("description") || desc == "":
+		c.add(SkillFile, 1, SeverityError, "frontmatter-description", "frontmatter is missing required field 'description'")
+		return
+	case len(desc) > MaxDescriptionLength:
+		c.add(SkillFile, line, SeverityError, "description-length", "description is %d characters, maximum is %d", len(desc), MaxDescriptionLength)
+	case len(desc) < MinDescriptionLength:
+		c.add(SkillFile, line, SeverityWarning, "description-length", "description is %d characters, too short to trigger reliably", len(desc))
+	}
+
+	lower := strings.ToLower(desc)
+	hasTrigger := false
+	for _, w := range triggerWords {
+		if strings.Contains(lower, w) {
+			hasTrigger = true
+			break
+		}
+	}
+	if !hasTrigger {
+		c.add(SkillFile, line, SeverityWarning, "description-trigger", "description should say when to use the skill (e.g. \"Use when ...\")")
+	}
+
+	if n := bytes.Count(content, []byte("\n")); n > c.opts.MaxSkillLines {
+		c.add(SkillFile, 0, SeverityWarning, "file-size", "%s has %d lines, consider moving detail into reference files (limit %d)", SkillFile, n, c.opts.MaxSkillLines)
+	}
+}
+
+var linkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+
+func (c *checker) checkLinks(rel string, content []byte, files []string) {
+	known := make(map[string]bool, len(files))
+	for _, f := range files {
+		known[f] = true
+	}
+
+	inFence := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		for _, m := range linkPattern.FindAllStringSubmatch(line, -1) {
+			target := m[1]
+			if isExternal(target) {
+				continue
+			}
+			if idx := strings.Index(target, "#"); idx >= 0 {
+				target = target[:idx]
+			}
+			if target == "" {
+				continue
+			}
+
+			resolved := path.Clean(path.Join(path.Dir(rel), target))
+			if resolved == ".." || strings.HasPrefix(resolved, "../") {
+				c.add(rel, lineNo, SeverityError, "link-outside", "link %q points outside the skill directory", m[1])
+				continue
+			}
+			if !known[resolved] && !isDir(filepath.Join(c.dir, filepath.FromSlash(resolved))) {
+				c.add(rel, lineNo, SeverityError, "broken-link", "link %q does not resolve to a file in the skill", m[1])
+				continue
+			}
+			c.referenced[resolved] = true
+		}
+	}
+}
+
+func (c *checker) checkUnreferenced(files []string, contents map[string][]byte) {
+	var text bytes.Buffer
+	for _, f := range files {
+		if strings.EqualFold(path.Ext(f), ".md") {
+			text.Write(contents[f])
+			text.WriteByte('\n')
+		}
+	}
+	all := text.String()
+
+	for _, f := range files {
+		if f == SkillFile || c.referenced[f] || strings.HasPrefix(path.Base(f), ".") {
+			continue
+		}
+		// Plain mentions (e.g. `scripts/analyze.ts` in a code block) also count
+		if strings.Contains(all, f) {
+			continue
+		}
+		c.add(f, 0, SeverityWarning, "unreferenced", "file is not referenced from any markdown file in the skill")
+	}
+}
+
+// listFiles returns all files in a skill directory as slash-separated relative paths
+func listFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+func isExternal(target string) bool {
+	lower := strings.ToLower(target)
+	for _, prefix := range []string{"http://", "https://", "mailto:", "ftp://", "#", "/"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isDir(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.IsDir()
+}
+
+func invalidUTF8Line(data []byte) int {
+	line := 1
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size <= 1 {
+			return line
+		}
+		if r == '\n' {
+			line++
+		}
+		data = data[size:]
+	}
+	return 0
+}
+
+func formatSize(n int64) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
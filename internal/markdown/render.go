@@ -0,0 +1,304 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI sequences used when rendering with color
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiDim       = "\x1b[2m"
+	ansiItalic    = "\x1b[3m"
+	ansiUnderline = "\x1b[4m"
+	ansiCyan      = "\x1b[36m"
+	ansiBlue      = "\x1b[34m"
+)
+
+var (
+	headingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleRe     = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	bulletRe   = regexp.MustCompile(`^(\s*)([-*+])\s+(.*)$`)
+	orderedRe  = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	fenceRe    = regexp.MustCompile("^\\s*(```+|~~~+)\\s*(\\S*)")
+	tableSepRe = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	ansiRe     = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+	inlineRe = regexp.MustCompile("`[^`]+`|!?\\[[^\\]]*\\]\\([^)]*\\)|\\*\\*[^*]+\\*\\*|__[^_]+__|\\*[^*\\s][^*]*\\*|\\b_[^_\\s][^_]*_\\b")
+)
+
+// Render formats a markdown document for a terminal. Paragraphs and lists
+// are wrapped to width (0 disables wrapping); color enables ANSI styling.
+func Render(src []byte, width int, color bool) string {
+	r := &renderer{width: width, color: color}
+	lines := strings.Split(strings.ReplaceAll(string(src), "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.ReplaceAll(lines[i], "\t", "    ")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			r.flush()
+			r.blank()
+
+		case fenceRe.MatchString(line):
+			r.flush()
+			fence := fenceRe.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines); i++ {
+				if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+					break
+				}
+				code = append(code, strings.ReplaceAll(lines[i], "\t", "    "))
+			}
+			r.code(code)
+
+		case headingRe.MatchString(line):
+			r.flush()
+			m := headingRe.FindStringSubmatch(line)
+			r.heading(len(m[1]), m[2])
+
+		case ruleRe.MatchString(line):
+			r.flush()
+			r.emit(r.style(strings.Repeat("─", r.ruleWidth()), ansiDim))
+
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && tableSepRe.MatchString(lines[i+1]):
+			r.flush()
+			rows := [][]string{splitRow(trimmed)}
+			for i += 2; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				rows = append(rows, splitRow(strings.TrimSpace(lines[i])))
+			}
+			i--
+			r.table(rows)
+
+		case strings.HasPrefix(trimmed, ">"):
+			r.flush()
+			text := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			for _, l := range r.wrap(r.inline(text), r.width-2) {
+				r.emit(r.style("│ ", ansiDim) + l)
+			}
+
+		case bulletRe.MatchString(line):
+			r.flush()
+			m := bulletRe.FindStringSubmatch(line)
+			r.startItem(len(m[1]), "•", m[3])
+
+		case orderedRe.MatchString(line):
+			r.flush()
+			m := orderedRe.FindStringSubmatch(line)
+			r.startItem(len(m[1]), m[2], m[3])
+
+		case r.item != nil && strings.HasPrefix(line, " "):
+			// Continuation of a list item
+			r.item.text += " " + trimmed
+
+		default:
+			if r.item != nil {
+				r.flush()
+			}
+			r.para = append(r.para, trimmed)
+		}
+	}
+	r.flush()
+
+	return strings.TrimRight(r.out.String(), "\n") + "\n"
+}
+
+type renderer struct {
+	width int
+	color bool
+	out   strings.Builder
+
+	para      []string  // Lines of the paragraph being collected
+	item      *listItem // List item being collected
+	lastBlank bool
+}
+
+type listItem struct {
+	indent int
+	marker string
+	text   string
+}
+
+func (r *renderer) emit(line string) {
+	r.out.WriteString(line + "\n")
+	r.lastBlank = false
+}
+
+func (r *renderer) blank() {
+	if !r.lastBlank && r.out.Len() > 0 {
+		r.out.WriteString("\n")
+		r.lastBlank = true
+	}
+}
+
+// flush writes the pending paragraph or list item
+func (r *renderer) flush() {
+	if len(r.para) > 0 {
+		for _, l := range r.wrap(r.inline(strings.Join(r.para, " ")), r.width) {
+			r.emit(l)
+		}
+		r.para = nil
+	}
+	if it := r.item; it != nil {
+		indent := strings.Repeat("  ", it.indent/2+1)
+		prefix := indent + it.marker + " "
+		hang := strings.Repeat(" ", utf8.RuneCountInString(prefix))
+		for n, l := range r.wrap(r.inline(it.text), r.width-len(hang)) {
+			if n == 0 {
+				r.emit(prefix + l)
+			} else {
+				r.emit(hang + l)
+			}
+		}
+		r.item = nil
+	}
+}
+
+func (r *renderer) startItem(indent int, marker, text string) {
+	r.item = &listItem{indent: indent, marker: marker, text: strings.TrimSpace(text)}
+}
+
+func (r *renderer) heading(level int, text string) {
+	r.blank()
+	text = r.inline(text)
+	switch level {
+	case 1:
+		r.emit(r.style(strings.ToUpper(text), ansiBold+ansiUnderline))
+		r.emit(r.style(strings.Repeat("═", min(Width(text), r.ruleWidth())), ansiDim))
+	case 2:
+		r.emit(r.style(text, ansiBold))
+		r.emit(r.style(strings.Repeat("─", min(Width(text), r.ruleWidth())), ansiDim))
+	default:
+		r.emit(r.style(text, ansiBold))
+	}
+}
+
+func (r *renderer) code(lines []string) {
+	for _, l := range lines {
+		r.emit("    " + r.style(l, ansiCyan))
+	}
+}
+
+// table aligns the cells of a table; the first row is the header
+func (r *renderer) table(rows [][]string) {
+	var widths []int
+	for _, row := range rows {
+		for c, cell := range row {
+			w := Width(r.inline(cell))
+			if c == len(widths) {
+				widths = append(widths, w)
+			} else if w > widths[c] {
+				widths[c] = w
+			}
+		}
+	}
+
+	for n, row := range rows {
+		var cells []string
+		for c := range widths {
+			cell := ""
+			if c < len(row) {
+				cell = r.inline(row[c])
+			}
+			cell += strings.Repeat(" ", widths[c]-Width(cell))
+			if n == 0 {
+				cell = r.style(cell, ansiBold)
+			}
+			cells = append(cells, cell)
+		}
+		r.emit("  " + strings.Join(cells, "  "))
+		if n == 0 {
+			var rule []string
+			for _, w := range widths {
+				rule = append(rule, strings.Repeat("─", w))
+			}
+			r.emit("  " + r.style(strings.Join(rule, "  "), ansiDim))
+		}
+	}
+}
+
+// inline renders code spans, emphasis, links and images within a line
+func (r *renderer) inline(text string) string {
+	return inlineRe.ReplaceAllStringFunc(text, func(m string) string {
+		switch {
+		case strings.HasPrefix(m, "`"):
+			if r.color {
+				return r.style(strings.Trim(m, "`"), ansiCyan)
+			}
+			return m
+		case strings.HasPrefix(m, "**") || strings.HasPrefix(m, "__"):
+			return r.style(m[2:len(m)-2], ansiBold)
+		case strings.HasPrefix(m, "*") || strings.HasPrefix(m, "_"):
+			return r.style(m[1:len(m)-1], ansiItalic)
+		}
+
+		// Link or image: [text](url)
+		image := strings.HasPrefix(m, "!")
+		m = strings.TrimPrefix(m, "!")
+		end := strings.Index(m, "](")
+		label, url := m[1:end], m[end+2:len(m)-1]
+		if image {
+			label = "image: " + label
+		}
+		if label == "" || label == url {
+			return r.style(url, ansiBlue+ansiUnderline)
+		}
+		return r.style(label, ansiUnderline) + " " + r.style("("+url+")", ansiDim)
+	})
+}
+
+func (r *renderer) style(s, code string) string {
+	if !r.color || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+func (r *renderer) ruleWidth() int {
+	if r.width <= 0 {
+		return 40
+	}
+	return r.width
+}
+
+// wrap breaks styled text at spaces so each line is at most width visible
+// characters wide. Words longer than width are kept whole.
+func (r *renderer) wrap(text string, width int) []string {
+	if r.width <= 0 || Width(text) <= width {
+		return []string{text}
+	}
+	var lines []string
+	line, lineW := "", 0
+	for _, word := range strings.Fields(text) {
+		w := Width(word)
+		if lineW > 0 && lineW+1+w > width {
+			lines = append(lines, line)
+			line, lineW = "", 0
+		}
+		if lineW > 0 {
+			line += " "
+			lineW++
+		}
+		line += word
+		lineW += w
+	}
+	return append(lines, line)
+}
+
+// Width returns the number of visible characters in s, ignoring ANSI styling
+func Width(s string) int {
+	return utf8.RuneCountInString(ansiRe.ReplaceAllString(s, ""))
+}
+
+func splitRow(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	var cells []string
+	for _, cell := range strings.Split(line, "|") {
+		cells = append(cells, strings.TrimSpace(cell))
+	}
+	return cells
+}
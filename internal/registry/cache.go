@@ -16,7 +16,7 @@ const (
 // CacheEntry represents a cached registry entry
 type CacheEntry struct {
 	Data      *RegistryIndex `json:"data"`
-	Ref       string         `json:"ref"`
+	Key       string         `json:"key"` // Registry and ref, see CacheKey
 	FetchedAt time.Time      `json:"fetched_at"`
 }
 
@@ -35,9 +35,15 @@ func NewCache() *Cache {
 	}
 }
 
+// CacheKey identifies the index of one registry at one ref. Registries from
+// other repos share ref names like "main", so the repo is part of the key.
+func CacheKey(owner, repo, ref string) string {
+	return owner + "_" + repo + "_" + ref
+}
+
 // Get retrieves cached registry data if valid
-func (c *Cache) Get(ref string) (*RegistryIndex, bool) {
-	entry, err := c.loadEntry(ref)
+func (c *Cache) Get(key string) (*RegistryIndex, bool) {
+	entry, err := c.loadEntry(key)
 	if err != nil {
 		return nil, false
 	}
@@ -50,15 +56,24 @@ func (c *Cache) Get(ref string) (*RegistryIndex, bool) {
 	return entry.Data, true
 }
 
+// GetAny retrieves cached registry data however old it is
+func (c *Cache) GetAny(key string) (*RegistryIndex, bool) {
+	entry, err := c.loadEntry(key)
+	if err != nil {
+		return nil, false
+	}
+	return entry.Data, true
+}
+
 // Set stores registry data in cache
-func (c *Cache) Set(ref string, data *RegistryIndex) error {
+func (c *Cache) Set(key string, data *RegistryIndex) error {
 	entry := &CacheEntry{
 		Data:      data,
-		Ref:       ref,
+		Key:       key,
 		FetchedAt: time.Now(),
 	}
 
-	return c.saveEntry(ref, entry)
+	return c.saveEntry(key, entry)
 }
 
 // Clear removes all cached data
@@ -66,20 +81,19 @@ func (c *Cache) Clear() error {
 	return os.RemoveAll(c.dir)
 }
 
-// ClearRef removes cached data for a specific ref
-func (c *Cache) ClearRef(ref string) error {
-	path := c.getCachePath(ref)
+// ClearRef removes cached data for one registry and ref (a CacheKey)
+func (c *Cache) ClearRef(key string) error {
+	path := c.getCachePath(key)
 	return os.Remove(path)
 }
 
-func (c *Cache) getCachePath(ref string) string {
-	// Sanitize ref for filename
-	safeRef := sanitizeFilename(ref)
-	return filepath.Join(c.dir, safeRef+".json")
+func (c *Cache) getCachePath(key string) string {
+	// Sanitize key for filename
+	return filepath.Join(c.dir, sanitizeFilename(key)+".json")
 }
 
-func (c *Cache) loadEntry(ref string) (*CacheEntry, error) {
-	path := c.getCachePath(ref)
+func (c *Cache) loadEntry(key string) (*CacheEntry, error) {
+	path := c.getCachePath(key)
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -93,7 +107,7 @@ func (c *Cache) loadEntry(ref string) (*CacheEntry, error) {
 	return &entry, nil
 }
 
-func (c *Cache) saveEntry(ref string, entry *CacheEntry) error {
+func (c *Cache) saveEntry(key string, entry *CacheEntry) error {
 	// Ensure cache directory exists
 	if err := os.MkdirAll(c.dir, 0755); err != nil {
 		return err
@@ -104,7 +118,7 @@ func (c *Cache) saveEntry(ref string, entry *CacheEntry) error {
 		return err
 	}
 
-	path := c.getCachePath(ref)
+	path := c.getCachePath(key)
 	return os.WriteFile(path, data, 0644)
 }
 
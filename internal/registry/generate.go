@@ -0,0 +1,371 @@
+package registry
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cuongtl1992/vibe-skills/internal/frontmatter"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	IndexFileName = "registry.json"
+	IndexVersion  = "1.0"
+
+	// BundlesDir holds one <bundle-name>.yaml per bundle, inside the skills directory
+	BundlesDir = "bundles"
+
+	// DetectDir holds one <rule-name>.yaml per detection rule, inside the skills directory
+	DetectDir = "detect"
+
+	maxIndexDescription = 200
+)
+
+// GenerateIndex scans a skills directory (skills/<stack>/<name>/SKILL.md) and
+// builds the registry index. scripts/generate-registry.sh and 'vibe-skills new'
+// both use it, so registry.json has a single definition.
+func GenerateIndex(skillsDir string) (*RegistryIndex, error) {
+	var skillFiles []string
+	err := filepath.WalkDir(skillsDir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && d.Name() == "SKILL.md" {
+			skillFiles = append(skillFiles, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(skillFiles)
+
+	index := &RegistryIndex{Version: IndexVersion, Skills: []Skill{}}
+	for _, skillFile := range skillFiles {
+		rel, err := filepath.Rel(skillsDir, skillFile)
+		if err != nil {
+			return nil, err
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) < 3 {
+			continue
+		}
+
+		content, err := os.ReadFile(skillFile)
+		if err != nil {
+			return nil, err
+		}
+
+		skill := Skill{
+			Name:  parts[1],
+			Stack: parts[0],
+			Path:  filepath.ToSlash(rel),
+		}
+
+		fm, body, err := frontmatter.Parse(content)
+		if err != nil {
+			body = content
+		}
+		if fm != nil && fm.Name != "" {
+			skill.Name = fm.Name
+		}
+		if fm != nil && fm.Description != "" {
+			skill.Description = fm.Description
+		} else {
+			skill.Description = firstTextLine(body)
+		}
+		if fm != nil {
+			skill.Version = fm.Version
+		}
+		skill.Description = truncate(skill.Description, maxIndexDescription)
+
+		files, err := skillFileList(filepath.Dir(skillFile))
+		if err != nil {
+			return nil, err
+		}
+		skill.Files = files
+
+		skill.Hashes, err = hashSkillFiles(filepath.Dir(skillFile), files)
+		if err != nil {
+			return nil, err
+		}
+
+		index.Skills = append(index.Skills, skill)
+	}
+
+	index.Bundles, err = loadBundles(filepath.Join(skillsDir, BundlesDir), index.Skills)
+	if err != nil {
+		return nil, err
+	}
+	index.Detect, err = loadDetectRules(filepath.Join(skillsDir, DetectDir), index.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// truncate shortens s to at most max bytes without splitting a UTF-8 character
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	end := 0
+	for end < len(s) {
+		_, size := utf8.DecodeRuneInString(s[end:])
+		if end+size > max {
+			break
+		}
+		end += size
+	}
+	return s[:end]
+}
+
+// loadBundles reads bundles/<name>.yaml files, each with a description and a
+// list of stack/name skills that must exist in the index
+func loadBundles(dir string, skills []Skill) ([]Bundle, error) {
+	var bundles []Bundle
+	err := loadDefinitions(dir, skills, func(name string, def definition) {
+		bundles = append(bundles, Bundle{Name: name, Description: def.Description, Skills: def.Skills})
+	})
+	return bundles, err
+}
+
+// loadDetectRules reads detect/<name>.yaml files, each with file globs, an
+// optional text to look for and the stack/name skills to suggest
+func loadDetectRules(dir string, skills []Skill) ([]DetectRule, error) {
+	var rules []DetectRule
+	err := loadDefinitions(dir, skills, func(name string, def definition) {
+		rules = append(rules, DetectRule{
+			Name:        name,
+			Description: def.Description,
+			Files:       def.Files,
+			Contains:    def.Contains,
+			Skills:      def.Skills,
+		})
+	})
+	return rules, err
+}
+
+// definition is the YAML layout shared by bundle and detection rule files
+type definition struct {
+	Description string   `yaml:"description"`
+	Files       []string `yaml:"files"`
+	Contains    string   `yaml:"contains"`
+	Skills      []string `yaml:"skills"`
+}
+
+// loadDefinitions parses every <name>.yaml in dir in name order, checking
+// that the skills they reference exist
+func loadDefinitions(dir string, skills []Skill, add func(name string, def definition)) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	known := make(map[string]bool)
+	for _, s := range skills {
+		known[s.Stack+"/"+s.Name] = true
+	}
+
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		var def definition
+		if err := yaml.Unmarshal(data, &def); err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(p), ".yaml")
+		for _, skill := range def.Skills {
+			if !known[skill] {
+				return fmt.Errorf("%s/%s: unknown skill %s (use stack/name)", filepath.Base(dir), name, skill)
+			}
+		}
+		add(name, def)
+	}
+	return nil
+}
+
+// WriteIndex writes the index as registry.json, with a stable layout so
+// regenerating an unchanged tree produces no diff
+func WriteIndex(path string, index *RegistryIndex) error {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+	buf.WriteString(`  "version": ` + jsonString(index.Version) + ",\n")
+	buf.WriteString("  \"skills\": [\n")
+	for i, s := range index.Skills {
+		files := make([]string, len(s.Files))
+		for j, f := range s.Files {
+			files[j] = jsonString(f)
+		}
+
+		buf.WriteString("    {\n")
+		buf.WriteString(`      "name": ` + jsonString(s.Name) + ",\n")
+		buf.WriteString(`      "stack": ` + jsonString(s.Stack) + ",\n")
+		buf.WriteString(`      "description": ` + jsonString(s.Description) + ",\n")
+		buf.WriteString(`      "path": ` + jsonString(s.Path) + ",\n")
+		if s.Version != "" {
+			buf.WriteString(`      "version": ` + jsonString(s.Version) + ",\n")
+		}
+		buf.WriteString(`      "files": [` + strings.Join(files, ", ") + "]")
+		if len(s.Hashes) > 0 {
+			buf.WriteString(",\n      \"hashes\": {\n")
+			paths := hashOrder(s.Hashes)
+			for j, p := range paths {
+				buf.WriteString("        " + jsonString(p) + ": " + jsonString(s.Hashes[p]))
+				if j < len(paths)-1 {
+					buf.WriteString(",")
+				}
+				buf.WriteString("\n")
+			}
+			buf.WriteString("      }")
+		}
+		buf.WriteString("\n    }")
+		if i < len(index.Skills)-1 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("  ]")
+	if len(index.Bundles) > 0 {
+		buf.WriteString(",\n  \"bundles\": [\n")
+		for i, b := range index.Bundles {
+			buf.WriteString("    {\n")
+			buf.WriteString(`      "name": ` + jsonString(b.Name) + ",\n")
+			buf.WriteString(`      "description": ` + jsonString(b.Description) + ",\n")
+			buf.WriteString(`      "skills": [` + jsonStrings(b.Skills) + "]\n")
+			buf.WriteString("    }")
+			if i < len(index.Bundles)-1 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString("  ]")
+	}
+	if len(index.Detect) > 0 {
+		buf.WriteString(",\n  \"detect\": [\n")
+		for i, r := range index.Detect {
+			buf.WriteString("    {\n")
+			buf.WriteString(`      "name": ` + jsonString(r.Name) + ",\n")
+			buf.WriteString(`      "description": ` + jsonString(r.Description) + ",\n")
+			buf.WriteString(`      "files": [` + jsonStrings(r.Files) + "],\n")
+			if r.Contains != "" {
+				buf.WriteString(`      "contains": ` + jsonString(r.Contains) + ",\n")
+			}
+			buf.WriteString(`      "skills": [` + jsonStrings(r.Skills) + "]\n")
+			buf.WriteString("    }")
+			if i < len(index.Detect)-1 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n")
+		}
+		buf.WriteString("  ]")
+	}
+	buf.WriteString("\n}\n")
+
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// skillFileList returns the files of a multi-file skill, or nil when the
+// skill consists of SKILL.md only. Hidden files are excluded.
+func skillFileList(dir string) ([]string, error) {
+	var extra []string
+	err := filepath.WalkDir(dir, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel != "SKILL.md" {
+			extra = append(extra, rel)
+		}
+		return nil
+	})
+	if err != nil || len(extra) == 0 {
+		return []string{}, err
+	}
+	sort.Strings(extra)
+	return append([]string{"SKILL.md"}, extra...), nil
+}
+
+// hashSkillFiles returns the sha256 of every file of a skill, or of SKILL.md
+// alone when files is empty
+func hashSkillFiles(dir string, files []string) (map[string]string, error) {
+	if len(files) == 0 {
+		files = []string{"SKILL.md"}
+	}
+	hashes := make(map[string]string, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(f)))
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		hashes[f] = hex.EncodeToString(sum[:])
+	}
+	return hashes, nil
+}
+
+// hashOrder lists hashed paths as the files list does: SKILL.md first, then sorted
+func hashOrder(hashes map[string]string) []string {
+	var paths []string
+	for p := range hashes {
+		if p != "SKILL.md" {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	if _, ok := hashes["SKILL.md"]; ok {
+		paths = append([]string{"SKILL.md"}, paths...)
+	}
+	return paths
+}
+
+// firstTextLine returns the first line that is not a header, blank or a code fence
+func firstTextLine(body []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "```") {
+			continue
+		}
+		return line
+	}
+	return ""
+}
+
+// jsonStrings renders the items of a one-line JSON string array
+func jsonStrings(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = jsonString(item)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func jsonString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/cuongtl1992/vibe-skills/internal/fuzzy"
 )
 
 const (
@@ -14,6 +16,7 @@ const (
 	DefaultRepo   = "vibe-skills"
 	DefaultBranch = "main"
 	RawGitHubURL  = "https://raw.githubusercontent.com"
+	GitHubAPIURL  = "https://api.github.com"
 )
 
 // GitHubRegistry fetches skills from GitHub
@@ -23,7 +26,9 @@ type GitHubRegistry struct {
 	ref     string // branch, tag, or commit
 	cache   *Cache
 	noCache bool
+	offline bool
 	client  *http.Client
+	commit  string // Resolved commit for ref, fetched lazily
 }
 
 // GitHubRegistryOptions configures the GitHub registry
@@ -33,6 +38,7 @@ type GitHubRegistryOptions struct {
 	Branch  string
 	Ref     string // Takes precedence over Branch if set
 	NoCache bool   // Skip cache and fetch fresh from registry
+	Offline bool   // Use cached data regardless of age and never touch the network
 }
 
 // NewGitHubRegistry creates a new GitHub-based registry
@@ -65,6 +71,7 @@ func NewGitHubRegistry(opts *GitHubRegistryOptions) *GitHubRegistry {
 		ref:     ref,
 		cache:   NewCache(),
 		noCache: opts.NoCache,
+		offline: opts.Offline,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
@@ -122,6 +129,7 @@ func (g *GitHubRegistry) Find(name string) (*Skill, error) {
 		return nil, err
 	}
 
+	var names []string
 	for _, s := range skills {
 		// Match by name only
 		if s.Name == name {
@@ -131,8 +139,44 @@ func (g *GitHubRegistry) Find(name string) (*Skill, error) {
 		if s.Stack+"/"+s.Name == name {
 			return &s, nil
 		}
+		names = append(names, s.Stack+"/"+s.Name)
+	}
+	return nil, &NotFoundError{Name: name, Suggestions: fuzzy.Suggest(name, names)}
+}
+
+// ListBundles returns the bundles the registry publishes
+func (g *GitHubRegistry) ListBundles() ([]Bundle, error) {
+	index, err := g.fetchIndex()
+	if err != nil {
+		return nil, err
+	}
+	return index.Bundles, nil
+}
+
+// FindBundle returns a bundle by name
+func (g *GitHubRegistry) FindBundle(name string) (*Bundle, error) {
+	bundles, err := g.ListBundles()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, b := range bundles {
+		if b.Name == name {
+			return &b, nil
+		}
+		names = append(names, b.Name)
 	}
-	return nil, fmt.Errorf("skill not found: %s", name)
+	return nil, fmt.Errorf("bundle not found: %s%s", name, fuzzy.DidYouMean(fuzzy.Suggest(name, names)))
+}
+
+// DetectRules returns the rules mapping project signals to skills
+func (g *GitHubRegistry) DetectRules() ([]DetectRule, error) {
+	index, err := g.fetchIndex()
+	if err != nil {
+		return nil, err
+	}
+	return index.Detect, nil
 }
 
 // Search returns skills matching the query
@@ -177,17 +221,12 @@ func (g *GitHubRegistry) GetFiles(skill *Skill) (map[string][]byte, error) {
 		return files, nil
 	}
 
-	// Get skill directory from path (e.g., "dotnet/clean-architecture" from "dotnet/clean-architecture/SKILL.md")
-	skillDir := strings.TrimSuffix(skill.Path, "/SKILL.md")
-
 	for _, filePath := range skill.Files {
 		if filePath == "SKILL.md" {
 			continue // Already fetched
 		}
 
-		// Build URL: skills/{stack}/{folder}/{filePath}
-		url := g.buildRawURL("skills/" + skillDir + "/" + filePath)
-		data, err := g.fetch(url)
+		data, err := g.GetFile(skill, filePath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch %s: %w", filePath, err)
 		}
@@ -197,11 +236,27 @@ func (g *GitHubRegistry) GetFiles(skill *Skill) (map[string][]byte, error) {
 	return files, nil
 }
 
+// GetFile returns one file of a skill by its path relative to the skill folder
+func (g *GitHubRegistry) GetFile(skill *Skill, filePath string) ([]byte, error) {
+	// Get skill directory from path (e.g., "dotnet/clean-architecture" from "dotnet/clean-architecture/SKILL.md")
+	skillDir := strings.TrimSuffix(skill.Path, "/SKILL.md")
+
+	// Build URL: skills/{stack}/{folder}/{filePath}
+	return g.fetch(g.buildRawURL("skills/" + skillDir + "/" + filePath))
+}
+
 // fetchIndex fetches and caches the registry index
 func (g *GitHubRegistry) fetchIndex() (*RegistryIndex, error) {
+	if g.offline {
+		if cached, ok := g.cache.GetAny(g.cacheKey()); ok {
+			return cached, nil
+		}
+		return nil, fmt.Errorf("registry for ref %s is not cached: run once online first", g.ref)
+	}
+
 	// Try cache first (unless --no-cache flag is set)
 	if !g.noCache {
-		if cached, ok := g.cache.Get(g.ref); ok {
+		if cached, ok := g.cache.Get(g.cacheKey()); ok {
 			return cached, nil
 		}
 	}
@@ -220,11 +275,16 @@ func (g *GitHubRegistry) fetchIndex() (*RegistryIndex, error) {
 
 	// Cache the result (best-effort, ignore error)
 	//nolint:errcheck
-	g.cache.Set(g.ref, &index)
+	g.cache.Set(g.cacheKey(), &index)
 
 	return &index, nil
 }
 
+// cacheKey identifies this registry's index in the cache
+func (g *GitHubRegistry) cacheKey() string {
+	return CacheKey(g.owner, g.repo, g.ref)
+}
+
 // buildRawURL builds a raw GitHub content URL
 func (g *GitHubRegistry) buildRawURL(path string) string {
 	return fmt.Sprintf("%s/%s/%s/%s/%s", RawGitHubURL, g.owner, g.repo, g.ref, path)
@@ -232,6 +292,10 @@ func (g *GitHubRegistry) buildRawURL(path string) string {
 
 // fetch performs an HTTP GET request
 func (g *GitHubRegistry) fetch(url string) ([]byte, error) {
+	if g.offline {
+		return nil, fmt.Errorf("offline: not fetching %s", url)
+	}
+
 	resp, err := g.client.Get(url)
 	if err != nil {
 		return nil, err
@@ -254,7 +318,55 @@ func (g *GitHubRegistry) GetRef() string {
 	return g.ref
 }
 
+// Source returns the registry location, e.g. github.com/owner/repo
+func (g *GitHubRegistry) Source() string {
+	return "github.com/" + g.owner + "/" + g.repo
+}
+
+// ResolveCommit returns the commit SHA the ref currently points to
+func (g *GitHubRegistry) ResolveCommit() (string, error) {
+	if g.commit != "" {
+		return g.commit, nil
+	}
+	if g.offline {
+		return "", fmt.Errorf("offline: cannot resolve %s", g.ref)
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/commits/%s", GitHubAPIURL, g.owner, g.repo, g.ref)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github.sha")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, url)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	g.commit = strings.TrimSpace(string(data))
+	return g.commit, nil
+}
+
+// ParseSource splits a source written by Source into owner and repo
+func ParseSource(source string) (owner, repo string, err error) {
+	parts := strings.Split(strings.TrimPrefix(source, "github.com/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unsupported registry source: %s", source)
+	}
+	return parts[0], parts[1], nil
+}
+
 // ClearCache clears the registry cache
 func (g *GitHubRegistry) ClearCache() error {
-	return g.cache.ClearRef(g.ref)
+	return g.cache.ClearRef(g.cacheKey())
 }
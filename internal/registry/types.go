@@ -1,18 +1,53 @@
 package registry
 
+import "github.com/cuongtl1992/vibe-skills/internal/fuzzy"
+
 // Skill represents a skill in the registry
 type Skill struct {
 	Name        string   `json:"name"`
 	Stack       string   `json:"stack"`
 	Description string   `json:"description"`
 	Path        string   `json:"path"`
-	Files       []string `json:"files,omitempty"` // Additional files for multi-file skills
+	Version     string   `json:"version,omitempty"` // Optional skill version from SKILL.md frontmatter
+	Files       []string `json:"files,omitempty"`   // Additional files for multi-file skills
+
+	// Hashes maps each file (including SKILL.md) to its sha256, so installed
+	// skills can be checked for updates without downloading them
+	Hashes map[string]string `json:"hashes,omitempty"`
+}
+
+// NotFoundError is returned by Find for a name that matches no skill
+type NotFoundError struct {
+	Name        string
+	Suggestions []string // Closest skill names, best first
+}
+
+func (e *NotFoundError) Error() string {
+	return "skill not found: " + e.Name + fuzzy.DidYouMean(e.Suggestions)
+}
+
+// Bundle is a curated set of skills published by a registry
+type Bundle struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Skills      []string `json:"skills"` // stack/name of each skill
+}
+
+// DetectRule maps a project signal to the skills `init` suggests for it
+type DetectRule struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Files       []string `json:"files"`              // Globs on file names, or on trailing path segments when they contain '/'
+	Contains    string   `json:"contains,omitempty"` // Text a matching file must contain
+	Skills      []string `json:"skills"`             // stack/name of each skill
 }
 
 // RegistryIndex represents the registry.json structure
 type RegistryIndex struct {
-	Version string  `json:"version"`
-	Skills  []Skill `json:"skills"`
+	Version string       `json:"version"`
+	Skills  []Skill      `json:"skills"`
+	Bundles []Bundle     `json:"bundles,omitempty"`
+	Detect  []DetectRule `json:"detect,omitempty"`
 }
 
 // Registry defines the interface for skill registries
@@ -29,12 +64,24 @@ type Registry interface {
 	// Find returns a skill by name (supports both "skill-name" and "stack/skill-name")
 	Find(name string) (*Skill, error)
 
+	// ListBundles returns the bundles the registry publishes
+	ListBundles() ([]Bundle, error)
+
+	// FindBundle returns a bundle by name
+	FindBundle(name string) (*Bundle, error)
+
+	// DetectRules returns the rules mapping project signals to skills
+	DetectRules() ([]DetectRule, error)
+
 	// Search returns skills matching the query
 	Search(query string) ([]Skill, error)
 
 	// GetContent returns the content of a skill's SKILL.md
 	GetContent(skill *Skill) ([]byte, error)
 
+	// GetFile returns one file of a skill by its path relative to the skill folder
+	GetFile(skill *Skill, path string) ([]byte, error)
+
 	// GetFiles returns all files for a multi-file skill
 	// Returns map of relative path -> content
 	GetFiles(skill *Skill) (map[string][]byte, error)
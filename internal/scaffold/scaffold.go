@@ -0,0 +1,273 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/cuongtl1992/vibe-skills/internal/frontmatter"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+	"gopkg.in/yaml.v3"
+)
+
+// Template names accepted by --template
+const (
+	TemplateReference = "reference"
+	TemplateWorkflow  = "workflow"
+	TemplateChecklist = "checklist"
+)
+
+// Templates lists the available templates in display order
+var Templates = []string{TemplateWorkflow, TemplateReference, TemplateChecklist}
+
+// Options configures a new skill
+type Options struct {
+	Stack       string
+	Name        string
+	Description string
+	Template    string
+	References  bool // Create references/ with a starter file
+	Scripts     bool // Create scripts/ with a starter file
+}
+
+// Result describes what was created
+type Result struct {
+	Dir             string
+	Files           []string
+	RegistryUpdated bool
+}
+
+// ParseName splits "stack/name" and validates both parts
+func ParseName(qualified string) (stack, name string, err error) {
+	parts := strings.Split(qualified, "/")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("skill must be given as <stack>/<name>: %s", qualified)
+	}
+	stack, name = parts[0], parts[1]
+	if !frontmatter.ValidName(stack) {
+		return "", "", fmt.Errorf("invalid stack %q: use lowercase letters, digits and hyphens", stack)
+	}
+	if !frontmatter.ValidName(name) {
+		return "", "", fmt.Errorf("invalid skill name %q: use lowercase letters, digits and hyphens", name)
+	}
+	return stack, name, nil
+}
+
+// Create writes a new skill below skillsDir and refreshes skillsDir/registry.json if present
+func Create(skillsDir string, opts *Options) (*Result, error) {
+	if opts.Template == "" {
+		opts.Template = TemplateWorkflow
+	}
+	tmpl, ok := templates[opts.Template]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q (available: %s)", opts.Template, strings.Join(Templates, ", "))
+	}
+	if opts.Description == "" {
+		opts.Description = fmt.Sprintf("Describe what %s does. Use when ...", opts.Name)
+	}
+
+	if err := checkCollision(skillsDir, opts.Name); err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(skillsDir, opts.Stack, opts.Name)
+	files := map[string]string{}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, opts); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	files["SKILL.md"] = buf.String()
+
+	if opts.References {
+		files["references/overview.md"] = fmt.Sprintf("# %s Reference\n\nDetailed material that SKILL.md links to. Keep SKILL.md short and move\nlong tables, API details and edge cases here.\n", title(opts.Name))
+	}
+	if opts.Scripts {
+		files["scripts/run.sh"] = "#!/bin/bash\n\n# Helper script invoked from SKILL.md\nset -e\n"
+	}
+
+	names := make([]string, 0, len(files))
+	for rel := range files {
+		names = append(names, rel)
+	}
+	sort.Strings(names)
+
+	result := &Result{Dir: dir}
+	for _, rel := range names {
+		content := files[rel]
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		mode := os.FileMode(0644)
+		if strings.HasPrefix(rel, "scripts/") {
+			mode = 0755
+		}
+		if err := os.WriteFile(path, []byte(content), mode); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+		result.Files = append(result.Files, filepath.Join(dir, filepath.FromSlash(rel)))
+	}
+
+	indexPath := filepath.Join(skillsDir, registry.IndexFileName)
+	if _, err := os.Stat(indexPath); err == nil {
+		index, err := registry.GenerateIndex(skillsDir)
+		if err != nil {
+			return result, fmt.Errorf("skill created but failed to refresh %s: %w", indexPath, err)
+		}
+		if err := registry.WriteIndex(indexPath, index); err != nil {
+			return result, fmt.Errorf("skill created but failed to write %s: %w", indexPath, err)
+		}
+		result.RegistryUpdated = true
+	}
+
+	return result, nil
+}
+
+// checkCollision rejects names already used by a skill in the tree or registry.json
+func checkCollision(skillsDir, name string) error {
+	index, err := registry.GenerateIndex(skillsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to scan %s: %w", skillsDir, err)
+	}
+	if index != nil {
+		for _, s := range index.Skills {
+			if s.Name == name {
+				return fmt.Errorf("skill already exists: %s/%s", s.Stack, s.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func title(name string) string {
+	words := strings.Split(name, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// yamlScalar renders a string as a YAML scalar, quoting it only when needed
+func yamlScalar(s string) string {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return s
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+var templates = map[string]*template.Template{
+	TemplateWorkflow:  mustTemplate(workflowTemplate),
+	TemplateReference: mustTemplate(referenceTemplate),
+	TemplateChecklist: mustTemplate(checklistTemplate),
+}
+
+func mustTemplate(text string) *template.Template {
+	return template.Must(template.New("SKILL.md").Funcs(template.FuncMap{"title": title, "yaml": yamlScalar}).Parse(text))
+}
+
+const header = `---
+name: {{.Name}}
+description: {{yaml .Description}}
+---
+
+# {{title .Name}}
+
+> One sentence on what this skill helps Claude do.
+
+## When to Use
+
+- Situation where this skill applies
+- Another trigger, e.g. a file type or kind of request
+`
+
+const footer = `{{if or .References .Scripts}}
+## Resources
+{{if .References}}
+- [overview.md](references/overview.md) - Detailed reference material
+{{- end}}
+{{- if .Scripts}}
+- [run.sh](scripts/run.sh) - Helper script
+{{- end}}
+{{end}}`
+
+const workflowTemplate = header + `
+---
+
+## Workflow
+
+### Step 1: Gather Context
+
+Describe what to read or ask before starting.
+
+### Step 2: Do the Work
+
+Describe the main steps, in order.
+
+### Step 3: Verify
+
+Describe how to check the result.
+
+---
+
+## Examples
+
+### Example: Typical Request
+
+**Input:** "..."
+
+**Output:**
+` + "```" + `
+...
+` + "```" + `
+` + footer
+
+const referenceTemplate = header + `
+## Quick Reference
+
+| Topic | Guidance |
+|-------|----------|
+| ... | ... |
+
+## Guidelines
+
+- Specific instruction 1
+- Specific instruction 2
+
+## Examples
+
+### Good
+` + "```" + `
+...
+` + "```" + `
+
+### Bad
+` + "```" + `
+...
+` + "```" + `
+` + footer
+
+const checklistTemplate = header + `
+## Checklist
+
+### Area 1
+
+- [ ] Check 1
+- [ ] Check 2
+
+### Area 2
+
+- [ ] Check 1
+- [ ] Check 2
+
+## Examples
+
+**Finding:** describe an issue and how to report it.
+` + footer
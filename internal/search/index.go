@@ -0,0 +1,416 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/cuongtl1992/vibe-skills/internal/fuzzy"
+)
+
+// BM25 parameters
+const (
+	k1 = 1.2
+	b  = 0.75
+
+	// metaBoost weighs matches in a skill's name, stack and description
+	// above matches in its files
+	metaBoost = 2.0
+)
+
+// Document is one piece of text belonging to a skill: its metadata (File
+// empty) or one of its files
+type Document struct {
+	Stack string
+	Name  string
+	File  string
+	Text  string
+}
+
+// Result is a skill matching a query
+type Result struct {
+	Stack   string   `json:"stack"`
+	Name    string   `json:"name"`
+	Score   float64  `json:"score"`
+	File    string   `json:"file"`    // File the snippet comes from, empty for the description
+	Snippet string   `json:"snippet"` // Text around the best match
+	Matches [][2]int `json:"-"`       // Byte ranges of matched words in Snippet
+}
+
+// Index ranks skills against queries with BM25
+type Index struct {
+	docs   []*indexedDoc
+	df     map[string]int // Documents containing each term
+	avgLen float64
+}
+
+type indexedDoc struct {
+	Document
+	tokens []token
+	tf     map[string]int
+}
+
+// token is a normalized word and its byte range in the document text
+type token struct {
+	term       string
+	start, end int
+}
+
+// NewIndex indexes documents
+func NewIndex(docs []Document) *Index {
+	ix := &Index{df: make(map[string]int)}
+	total := 0
+	for _, d := range docs {
+		doc := &indexedDoc{Document: d, tokens: tokenize(d.Text), tf: make(map[string]int)}
+		for _, t := range doc.tokens {
+			doc.tf[t.term]++
+		}
+		for term := range doc.tf {
+			ix.df[term]++
+		}
+		total += len(doc.tokens)
+		ix.docs = append(ix.docs, doc)
+	}
+	if len(ix.docs) > 0 {
+		ix.avgLen = float64(total) / float64(len(ix.docs))
+	}
+	return ix
+}
+
+// Query is a parsed search query: loose terms and quoted phrases
+type Query struct {
+	Terms   []string   // Every distinct term, including those of phrases
+	Phrases [][]string // Term sequences that must appear in this order
+}
+
+// ParseQuery splits a query into terms and "quoted phrases"
+func ParseQuery(q string) Query {
+	var query Query
+	seen := make(map[string]bool)
+	add := func(terms []string) {
+		for _, t := range terms {
+			if !seen[t] {
+				seen[t] = true
+				query.Terms = append(query.Terms, t)
+			}
+		}
+	}
+
+	parts := strings.Split(q, `"`)
+	for i, part := range parts {
+		terms := terms(part)
+		// Odd parts were inside quotes; an unclosed quote is a phrase too
+		if i%2 == 1 && len(terms) > 1 {
+			query.Phrases = append(query.Phrases, terms)
+		}
+		add(terms)
+	}
+	return query
+}
+
+// Search returns the skills matching query, best first. Skills must contain
+// every phrase, and rank higher the more of the terms they contain. Terms
+// found nowhere are taken as typos of the closest indexed word.
+func (ix *Index) Search(q string, limit int) []Result {
+	query := ix.correct(ParseQuery(q))
+	if len(query.Terms) == 0 {
+		return nil
+	}
+
+	type hit struct {
+		result  Result
+		meta    float64
+		best    float64
+		bestDoc *indexedDoc
+		terms   map[string]bool
+		phrases map[int]bool
+	}
+	hits := make(map[string]*hit)
+	var order []string
+
+	for _, doc := range ix.docs {
+		score := ix.score(doc, query.Terms)
+		if score == 0 {
+			continue
+		}
+		key := doc.Stack + "/" + doc.Name
+		h, ok := hits[key]
+		if !ok {
+			h = &hit{
+				result:  Result{Stack: doc.Stack, Name: doc.Name},
+				terms:   make(map[string]bool),
+				phrases: make(map[int]bool),
+			}
+			hits[key] = h
+			order = append(order, key)
+		}
+		for _, t := range query.Terms {
+			if doc.tf[t] > 0 {
+				h.terms[t] = true
+			}
+		}
+		phraseHit := false
+		for n, p := range query.Phrases {
+			if doc.hasPhrase(p) {
+				h.phrases[n] = true
+				phraseHit = true
+			}
+		}
+
+		if doc.File == "" {
+			h.meta = score * metaBoost
+			if h.bestDoc == nil {
+				h.bestDoc = doc
+			}
+			continue
+		}
+		// Prefer files holding a phrase for the snippet
+		if phraseHit {
+			score *= 1.5
+		}
+		if score > h.best {
+			h.best, h.bestDoc = score, doc
+		}
+	}
+
+	var results []Result
+	for _, key := range order {
+		h := hits[key]
+		if len(h.phrases) < len(query.Phrases) {
+			continue
+		}
+		coverage := float64(len(h.terms)) / float64(len(query.Terms))
+		h.result.Score = math.Round((h.meta+h.best)*coverage*coverage*1000) / 1000
+
+		// Metadata matches need no snippet: the description is shown anyway
+		if doc := h.bestDoc; doc.File != "" {
+			h.result.File = doc.File
+			h.result.Snippet = snippet(doc, query)
+			h.result.Matches = highlights(h.result.Snippet, query.Terms)
+		}
+		results = append(results, h.result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// minCorrected is the shortest term correct replaces: shorter words have too
+// many neighbours to guess from
+const minCorrected = 4
+
+// correct replaces the terms no document contains with the closest indexed
+// term, so a misspelled word still finds what it was meant to
+func (ix *Index) correct(q Query) Query {
+	fixed := make(map[string]string)
+	var vocabulary []string
+	for _, t := range q.Terms {
+		if ix.df[t] > 0 || utf8.RuneCountInString(t) < minCorrected {
+			continue
+		}
+		if vocabulary == nil {
+			for term := range ix.df {
+				vocabulary = append(vocabulary, term)
+			}
+		}
+		if s := fuzzy.Suggest(t, vocabulary); len(s) > 0 {
+			fixed[t] = s[0]
+		}
+	}
+	if len(fixed) == 0 {
+		return q
+	}
+
+	replace := func(t string) string {
+		if f, ok := fixed[t]; ok {
+			return f
+		}
+		return t
+	}
+	corrected := Query{}
+	seen := make(map[string]bool)
+	for _, t := range q.Terms {
+		if t = replace(t); !seen[t] {
+			seen[t] = true
+			corrected.Terms = append(corrected.Terms, t)
+		}
+	}
+	for _, p := range q.Phrases {
+		phrase := make([]string, len(p))
+		for n, t := range p {
+			phrase[n] = replace(t)
+		}
+		corrected.Phrases = append(corrected.Phrases, phrase)
+	}
+	return corrected
+}
+
+// score is the BM25 score of a document for the terms
+func (ix *Index) score(doc *indexedDoc, terms []string) float64 {
+	n := float64(len(ix.docs))
+	length := float64(len(doc.tokens))
+	score := 0.0
+	for _, t := range terms {
+		tf := float64(doc.tf[t])
+		if tf == 0 {
+			continue
+		}
+		df := float64(ix.df[t])
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		score += idf * tf * (k1 + 1) / (tf + k1*(1-b+b*length/ix.avgLen))
+	}
+	return score
+}
+
+// hasPhrase reports whether the terms appear consecutively in the document
+func (d *indexedDoc) hasPhrase(phrase []string) bool {
+	return d.phraseAt(phrase) >= 0
+}
+
+// phraseAt returns the token index where phrase starts, or -1
+func (d *indexedDoc) phraseAt(phrase []string) int {
+	if d.tf[phrase[0]] == 0 {
+		return -1
+	}
+outer:
+	for i := 0; i+len(phrase) <= len(d.tokens); i++ {
+		for j, t := range phrase {
+			if d.tokens[i+j].term != t {
+				continue outer
+			}
+		}
+		return i
+	}
+	return -1
+}
+
+// snippetWords is the length of a snippet in words
+const snippetWords = 24
+
+// snippet returns a few words of the document around the spot matching the
+// most distinct query terms
+func snippet(d *indexedDoc, q Query) string {
+	wanted := make(map[string]bool)
+	for _, t := range q.Terms {
+		wanted[t] = true
+	}
+
+	// Start at a phrase when there is one, otherwise at the densest window
+	best, bestCount := -1, 0
+	for _, p := range q.Phrases {
+		if i := d.phraseAt(p); i >= 0 {
+			best, bestCount = i, len(q.Terms)
+			break
+		}
+	}
+	if best < 0 {
+		for i, t := range d.tokens {
+			if !wanted[t.term] {
+				continue
+			}
+			distinct := make(map[string]bool)
+			for j := i; j < len(d.tokens) && j < i+snippetWords*2/3; j++ {
+				if wanted[d.tokens[j].term] {
+					distinct[d.tokens[j].term] = true
+				}
+			}
+			if len(distinct) > bestCount {
+				best, bestCount = i, len(distinct)
+			}
+		}
+	}
+	if best < 0 || len(d.tokens) == 0 {
+		return ""
+	}
+
+	first := max(best-snippetWords/4, 0)
+	last := min(first+snippetWords, len(d.tokens)) - 1
+	text := d.Text[d.tokens[first].start:d.tokens[last].end]
+
+	prefix, suffix := "", ""
+	if first > 0 {
+		prefix = "…"
+	}
+	if last < len(d.tokens)-1 {
+		suffix = "…"
+	}
+	return prefix + strings.Join(strings.Fields(text), " ") + suffix
+}
+
+// highlights returns the byte ranges of words in s matching the terms
+func highlights(s string, terms []string) [][2]int {
+	wanted := make(map[string]bool)
+	for _, t := range terms {
+		wanted[t] = true
+	}
+	var ranges [][2]int
+	for _, t := range tokenize(s) {
+		if wanted[t.term] {
+			ranges = append(ranges, [2]int{t.start, t.end})
+		}
+	}
+	return ranges
+}
+
+// Highlight wraps each match in the snippet with before and after
+func (r Result) Highlight(before, after string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range r.Matches {
+		b.WriteString(r.Snippet[last:m[0]])
+		b.WriteString(before + r.Snippet[m[0]:m[1]] + after)
+		last = m[1]
+	}
+	b.WriteString(r.Snippet[last:])
+	return b.String()
+}
+
+// terms returns the normalized words of s
+func terms(s string) []string {
+	var out []string
+	for _, t := range tokenize(s) {
+		out = append(out, t.term)
+	}
+	return out
+}
+
+// tokenize splits text into lowercase words of letters and digits
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			tokens = append(tokens, newToken(text, start, i))
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, newToken(text, start, len(text)))
+	}
+	return tokens
+}
+
+func newToken(text string, start, end int) token {
+	return token{term: normalize(text[start:end]), start: start, end: end}
+}
+
+// normalize lowercases a word and strips a plural "s", so "Deadlocks"
+// matches "deadlock"
+func normalize(word string) string {
+	word = strings.ToLower(word)
+	if utf8.RuneCountInString(word) > 3 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+		word = word[:len(word)-1]
+	}
+	return word
+}
@@ -0,0 +1,94 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		q    string
+		want Query
+	}{
+		{q: "", want: Query{}},
+		{q: "Deadlocks SQL", want: Query{Terms: []string{"deadlock", "sql"}}},
+		{
+			q:    `"code review" go`,
+			want: Query{Terms: []string{"code", "review", "go"}, Phrases: [][]string{{"code", "review"}}},
+		},
+		{q: `"review" go`, want: Query{Terms: []string{"review", "go"}}},
+		{
+			q:    `go "table tests`,
+			want: Query{Terms: []string{"go", "table", "test"}, Phrases: [][]string{{"table", "test"}}},
+		},
+		{
+			q:    `"go test" go`,
+			want: Query{Terms: []string{"go", "test"}, Phrases: [][]string{{"go", "test"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			if got := ParseQuery(tt.q); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ix := NewIndex([]Document{
+		{Stack: "go", Name: "testing", Text: "testing\ngo\nWrite table tests for Go packages"},
+		{Stack: "go", Name: "testing", File: "SKILL.md", Text: "Use table driven tests. Run go test with the race detector."},
+		{Stack: "db", Name: "sql", Text: "sql\ndb\nQuery tuning and deadlock analysis"},
+		{Stack: "db", Name: "sql", File: "SKILL.md", Text: "Find slow queries. Tests for migrations live in a driven table."},
+		{Stack: "common", Name: "reviewer", Text: "reviewer\ncommon\nReview code changes"},
+		{Stack: "common", Name: "reviewer", File: "checklist.md", Text: "Check the race conditions and deadlocks in code under review."},
+	})
+
+	tests := []struct {
+		name string
+		q    string
+		want []string // stack/name, best first
+	}{
+		{name: "single term", q: "deadlock", want: []string{"db/sql", "common/reviewer"}},
+		{name: "phrase filters", q: `"table driven"`, want: []string{"go/testing"}},
+		{name: "phrase in any order is not a match", q: `"driven table"`, want: []string{"db/sql"}},
+		{name: "every phrase required", q: `"table driven" "race detector"`, want: []string{"go/testing"}},
+		{name: "missing phrase", q: `"race deadlock"`, want: nil},
+		{name: "coverage ranks first", q: "race deadlock review", want: []string{"common/reviewer", "db/sql", "go/testing"}},
+		{name: "typo corrected", q: "deadlcok", want: []string{"db/sql", "common/reviewer"}},
+		{name: "no match", q: "kubernetes", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range ix.Search(tt.q, 0) {
+				got = append(got, r.Stack+"/"+r.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchSnippet(t *testing.T) {
+	ix := NewIndex([]Document{
+		{Stack: "go", Name: "testing", Text: "testing\ngo\nGo tests"},
+		{Stack: "go", Name: "testing", File: "SKILL.md", Text: "Intro.\n\nAlways run the race detector in CI."},
+	})
+
+	results := ix.Search(`"race detector"`, 1)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	r := results[0]
+	if r.File != "SKILL.md" {
+		t.Errorf("File = %q, want SKILL.md", r.File)
+	}
+	if want := "Intro. Always run the [race] [detector] in CI"; r.Highlight("[", "]") != want {
+		t.Errorf("Highlight = %q, want %q", r.Highlight("[", "]"), want)
+	}
+}
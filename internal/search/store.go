@@ -0,0 +1,253 @@
+package search
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cuongtl1992/vibe-skills/internal/frontmatter"
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+const (
+	storeVersion = 1
+
+	// fetchWorkers is how many files Refresh downloads at once
+	fetchWorkers = 8
+
+	// maxFileSize skips files too large to be useful search text
+	maxFileSize = 512 * 1024
+)
+
+// textExtensions are the skill files worth indexing besides SKILL.md
+var textExtensions = map[string]bool{
+	".md": true, ".txt": true, ".rst": true,
+	".yaml": true, ".yml": true, ".json": true,
+	".sql": true, ".cs": true, ".go": true, ".ts": true, ".js": true, ".py": true, ".sh": true, ".dart": true,
+	".feature": true,
+}
+
+// Source is the registry the index is built from
+type Source interface {
+	GetFile(skill *registry.Skill, path string) ([]byte, error)
+	GetRef() string
+	ResolveCommit() (string, error)
+}
+
+// store is the on-disk form of the index: the text of every file, keyed by
+// stack/name/path, with the hash it was fetched at
+type store struct {
+	Version int                    `json:"version"`
+	Ref     string                 `json:"ref"` // Ref of the last refresh
+	Files   map[string]*storedFile `json:"files"`
+}
+
+type storedFile struct {
+	Hash string `json:"hash"` // sha256 from the registry index, or "commit:<sha>" ("ref:<ref>" offline) when it has none
+	Text string `json:"text"`
+}
+
+// RefreshOptions configures Refresh
+type RefreshOptions struct {
+	// Offline builds the index from the stored text only, however old,
+	// without downloading anything
+	Offline bool
+
+	// Fetching, when set, is called with the number of files to download
+	// before the downloads start
+	Fetching func(n int)
+}
+
+// RefreshStats counts the work done by Refresh
+type RefreshStats struct {
+	Fetched int // Files downloaded because they were new or changed
+	Failed  int // Files that could not be downloaded; older text is kept if any
+	Reused  int // Files whose stored text was current
+	Stale   int // Files not downloaded because offline; older text is kept if any
+}
+
+// Refresh brings the index stored at file up to date with the registry's
+// skills and returns it. Only files that are new or whose registry hash
+// changed are downloaded, a few at a time, so switching refs costs what
+// differs between them.
+func Refresh(file string, skills []registry.Skill, src Source, opts RefreshOptions) (*Index, *RefreshStats) {
+	st := loadStore(file)
+	ref := src.GetRef()
+	stats := &RefreshStats{}
+
+	// Files the registry index has no hash for are as current as the commit
+	// they were fetched at. A branch moves, so it is resolved to its commit,
+	// once and only when needed; offline, the ref name is the best there is.
+	var version string
+	fallbackHash := func() string {
+		if version == "" {
+			if commit, err := src.ResolveCommit(); err == nil && commit != "" {
+				version = "commit:" + commit
+			} else {
+				version = "ref:" + ref
+			}
+		}
+		return version
+	}
+
+	type pending struct {
+		skill *registry.Skill
+		path  string
+		key   string
+		hash  string
+	}
+	var todo []pending
+	current := make(map[string]bool)
+	for n := range skills {
+		skill := &skills[n]
+		for _, p := range indexedFiles(skill) {
+			key := skill.Stack + "/" + skill.Name + "/" + p
+			hash := skill.Hashes[p]
+			if hash == "" {
+				hash = fallbackHash()
+			}
+			current[key] = true
+			if f, ok := st.Files[key]; ok && f.Hash == hash {
+				stats.Reused++
+				continue
+			}
+			todo = append(todo, pending{skill: skill, path: p, key: key, hash: hash})
+		}
+	}
+
+	changed := st.Ref != ref
+	st.Ref = ref
+	for key := range st.Files {
+		if !current[key] {
+			delete(st.Files, key)
+			changed = true
+		}
+	}
+
+	if opts.Offline {
+		stats.Stale = len(todo)
+		todo = nil
+	}
+	if len(todo) > 0 && opts.Fetching != nil {
+		opts.Fetching(len(todo))
+	}
+
+	// Download on a few workers; results are applied here, in one goroutine
+	type fetched struct {
+		pending
+		data []byte
+		err  error
+	}
+	jobs := make(chan pending)
+	results := make(chan fetched)
+	for w := 0; w < min(fetchWorkers, len(todo)); w++ {
+		go func() {
+			for p := range jobs {
+				data, err := src.GetFile(p.skill, p.path)
+				results <- fetched{pending: p, data: data, err: err}
+			}
+		}()
+	}
+	go func() {
+		for _, p := range todo {
+			jobs <- p
+		}
+		close(jobs)
+	}()
+	for range todo {
+		r := <-results
+		if r.err != nil {
+			stats.Failed++
+			continue
+		}
+		st.Files[r.key] = &storedFile{Hash: r.hash, Text: searchText(r.path, r.data)}
+		stats.Fetched++
+		changed = true
+	}
+
+	if changed {
+		// The index is a cache: failing to save only costs a refetch
+		_ = saveStore(file, st)
+	}
+
+	var docs []Document
+	for n := range skills {
+		s := &skills[n]
+		docs = append(docs, Document{
+			Stack: s.Stack,
+			Name:  s.Name,
+			Text:  s.Name + "\n" + s.Stack + "\n" + s.Description,
+		})
+		for _, p := range indexedFiles(s) {
+			if f, ok := st.Files[s.Stack+"/"+s.Name+"/"+p]; ok && f.Text != "" {
+				docs = append(docs, Document{Stack: s.Stack, Name: s.Name, File: p, Text: f.Text})
+			}
+		}
+	}
+	return NewIndex(docs), stats
+}
+
+// indexedFiles lists the text files of a skill worth searching
+func indexedFiles(skill *registry.Skill) []string {
+	files := []string{"SKILL.md"}
+	for _, f := range skill.Files {
+		if f != "SKILL.md" && textExtensions[strings.ToLower(path.Ext(f))] {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// searchText returns the text of a file to index, without SKILL.md
+// frontmatter (already indexed as metadata). Binary or oversized files
+// index as empty text.
+func searchText(name string, data []byte) string {
+	if len(data) > maxFileSize || !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0 {
+		return ""
+	}
+	if name == "SKILL.md" {
+		data = frontmatter.Strip(data)
+	}
+	return string(data)
+}
+
+// StorePath returns where the index of a registry source is kept
+func StorePath(source string) string {
+	homeDir, _ := os.UserHomeDir()
+	sum := sha256.Sum256([]byte(source))
+	return filepath.Join(homeDir, registry.CacheDir, "cache", "search-"+hex.EncodeToString(sum[:6])+".json")
+}
+
+func loadStore(file string) *store {
+	st := &store{Version: storeVersion, Files: make(map[string]*storedFile)}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return st
+	}
+	var loaded store
+	if json.Unmarshal(data, &loaded) != nil || loaded.Version != storeVersion || loaded.Files == nil {
+		return st
+	}
+	return &loaded
+}
+
+func saveStore(file string, st *store) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
@@ -0,0 +1,84 @@
+package search
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/cuongtl1992/vibe-skills/internal/registry"
+)
+
+// fakeSource serves files from a map and counts downloads
+type fakeSource struct {
+	ref     string
+	commit  string // ResolveCommit fails when empty
+	files   map[string]string
+	fetched int
+}
+
+func (s *fakeSource) GetFile(skill *registry.Skill, p string) ([]byte, error) {
+	s.fetched++
+	text, ok := s.files[skill.Name+"/"+p]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(text), nil
+}
+
+func (s *fakeSource) GetRef() string { return s.ref }
+
+func (s *fakeSource) ResolveCommit() (string, error) {
+	if s.commit == "" {
+		return "", errors.New("offline")
+	}
+	return s.commit, nil
+}
+
+func TestRefreshUnhashedFiles(t *testing.T) {
+	skills := []registry.Skill{{Stack: "go", Name: "testing"}}
+	file := filepath.Join(t.TempDir(), "search.json")
+	src := &fakeSource{ref: "main", commit: "aaa", files: map[string]string{"testing/SKILL.md": "table tests"}}
+
+	steps := []struct {
+		name    string
+		commit  string
+		text    string
+		opts    RefreshOptions
+		want    RefreshStats
+		results int // Hits for "deadlock"
+	}{
+		{name: "first refresh", commit: "aaa", text: "table tests", want: RefreshStats{Fetched: 1}},
+		{name: "same commit", commit: "aaa", text: "table tests", want: RefreshStats{Reused: 1}},
+		{name: "branch moved", commit: "bbb", text: "deadlock", want: RefreshStats{Fetched: 1}, results: 1},
+		{name: "same commit again", commit: "bbb", text: "deadlock", want: RefreshStats{Reused: 1}, results: 1},
+		{name: "unresolved", commit: "", text: "changed", opts: RefreshOptions{Offline: true}, want: RefreshStats{Stale: 1}, results: 1},
+	}
+
+	for _, step := range steps {
+		src.commit, src.files["testing/SKILL.md"], src.fetched = step.commit, step.text, 0
+		ix, stats := Refresh(file, skills, src, step.opts)
+		if *stats != step.want {
+			t.Errorf("%s: stats = %+v, want %+v", step.name, *stats, step.want)
+		}
+		if src.fetched != step.want.Fetched {
+			t.Errorf("%s: %d download(s), want %d", step.name, src.fetched, step.want.Fetched)
+		}
+		if got := len(ix.Search("deadlock", 0)); got != step.results {
+			t.Errorf("%s: %d result(s) for deadlock, want %d", step.name, got, step.results)
+		}
+	}
+}
+
+func TestRefreshHashedFiles(t *testing.T) {
+	skills := []registry.Skill{{Stack: "go", Name: "testing", Hashes: map[string]string{"SKILL.md": "h1"}}}
+	file := filepath.Join(t.TempDir(), "search.json")
+	src := &fakeSource{ref: "main", files: map[string]string{"testing/SKILL.md": "table tests"}}
+
+	Refresh(file, skills, src, RefreshOptions{})
+
+	// A new ref with the same file hash downloads nothing
+	src.ref, src.fetched = "v2", 0
+	if _, stats := Refresh(file, skills, src, RefreshOptions{}); stats.Reused != 1 || src.fetched != 0 {
+		t.Errorf("stats = %+v after %d download(s), want the file reused", *stats, src.fetched)
+	}
+}
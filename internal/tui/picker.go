@@ -0,0 +1,392 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ANSI sequences used by the picker
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiReverse = "\x1b[7m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+)
+
+// Item is one skill the picker offers
+type Item struct {
+	Name        string
+	Stack       string
+	Description string
+	Installed   bool
+	Outdated    bool
+}
+
+// ID returns the stack/name of the item
+func (it Item) ID() string {
+	return it.Stack + "/" + it.Name
+}
+
+// Picker is a multi-select list of skills grouped by collapsible stacks, with
+// incremental search and a preview of the skill under the cursor
+type Picker struct {
+	items   []Item
+	preview func(Item) string
+
+	query      []rune
+	cursor     int // Index into rows()
+	offset     int // First visible row
+	previewTop int // First visible preview line
+	collapsed  map[string]bool
+	selected   map[string]bool
+}
+
+// row is a stack header (item == nil) or a skill
+type row struct {
+	stack string
+	item  *Item
+	count int // Matching skills, for stack headers
+}
+
+// NewPicker creates a picker over items. preview returns the text shown for
+// the skill under the cursor and may be slow the first time; cache if needed.
+func NewPicker(items []Item, preview func(Item) string) *Picker {
+	sorted := append([]Item{}, items...)
+	sort.Slice(sorted, func(a, b int) bool {
+		if sorted[a].Stack != sorted[b].Stack {
+			return sorted[a].Stack < sorted[b].Stack
+		}
+		return sorted[a].Name < sorted[b].Name
+	})
+	return &Picker{
+		items:     sorted,
+		preview:   preview,
+		collapsed: make(map[string]bool),
+		selected:  make(map[string]bool),
+	}
+}
+
+// Items returns every item in display order
+func (p *Picker) Items() []Item {
+	return append([]Item{}, p.items...)
+}
+
+// Selected returns the selected items in display order
+func (p *Picker) Selected() []Item {
+	var items []Item
+	for _, it := range p.items {
+		if p.selected[it.ID()] {
+			items = append(items, it)
+		}
+	}
+	return items
+}
+
+// matches reports whether an item contains every word of the query
+func (p *Picker) matches(it Item) bool {
+	text := strings.ToLower(it.Name + " " + it.Stack + " " + it.Description)
+	for _, word := range strings.Fields(strings.ToLower(string(p.query))) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// rows lists the visible stack headers and skills. While searching, stacks
+// with matches are expanded and the others hidden.
+func (p *Picker) rows() []row {
+	var rows []row
+	searching := len(p.query) > 0
+	for i := 0; i < len(p.items); {
+		stack := p.items[i].Stack
+		header := len(rows)
+		rows = append(rows, row{stack: stack})
+		for ; i < len(p.items) && p.items[i].Stack == stack; i++ {
+			if !p.matches(p.items[i]) {
+				continue
+			}
+			rows[header].count++
+			if searching || !p.collapsed[stack] {
+				rows = append(rows, row{stack: stack, item: &p.items[i]})
+			}
+		}
+		if rows[header].count == 0 {
+			rows = rows[:header]
+		}
+	}
+	return rows
+}
+
+// handle applies a key and reports whether the picker is finished and, if
+// so, whether it was confirmed
+func (p *Picker) handle(k key) (done, confirmed bool) {
+	rows := p.rows()
+	current := func() *row {
+		if p.cursor >= 0 && p.cursor < len(rows) {
+			return &rows[p.cursor]
+		}
+		return nil
+	}
+
+	switch k.code {
+	case keyCancel:
+		return true, false
+	case keyEnter:
+		if len(p.selected) == 0 {
+			if r := current(); r != nil && r.item != nil {
+				p.selected[r.item.ID()] = true
+			}
+		}
+		return true, true
+	case keyUp:
+		p.move(-1)
+	case keyDown:
+		p.move(1)
+	case keyHome:
+		p.cursor = 0
+	case keyEnd:
+		p.cursor = len(rows) - 1
+	case keyPageUp:
+		p.previewTop = max(0, p.previewTop-10)
+	case keyPageDown:
+		p.previewTop += 10
+	case keyLeft:
+		if r := current(); r != nil {
+			p.collapsed[r.stack] = true
+			p.cursor = p.headerIndex(r.stack)
+		}
+	case keyRight:
+		if r := current(); r != nil {
+			p.collapsed[r.stack] = false
+		}
+	case keyToggle:
+		r := current()
+		switch {
+		case r == nil:
+		case r.item != nil:
+			p.toggle(*r.item)
+		default:
+			p.toggleStack(r.stack)
+		}
+	case keyBackspace:
+		if len(p.query) > 0 {
+			p.setQuery(p.query[:len(p.query)-1])
+		}
+	case keyClear:
+		p.setQuery(nil)
+	case keyRune:
+		p.setQuery(append(p.query, k.r))
+	}
+	return false, false
+}
+
+func (p *Picker) move(delta int) {
+	n := len(p.rows())
+	if n == 0 {
+		return
+	}
+	p.cursor = min(max(p.cursor+delta, 0), n-1)
+	p.previewTop = 0
+}
+
+func (p *Picker) setQuery(q []rune) {
+	p.query = q
+	p.cursor, p.offset, p.previewTop = 0, 0, 0
+	// Start on the first skill rather than its stack header
+	if rows := p.rows(); len(rows) > 1 && rows[0].item == nil {
+		p.cursor = 1
+	}
+}
+
+func (p *Picker) headerIndex(stack string) int {
+	for i, r := range p.rows() {
+		if r.item == nil && r.stack == stack {
+			return i
+		}
+	}
+	return 0
+}
+
+func (p *Picker) toggle(it Item) {
+	if p.selected[it.ID()] {
+		delete(p.selected, it.ID())
+	} else {
+		p.selected[it.ID()] = true
+	}
+}
+
+// toggleStack selects every matching skill of a stack, or clears them when
+// all are already selected
+func (p *Picker) toggleStack(stack string) {
+	var members []Item
+	all := true
+	for _, it := range p.items {
+		if it.Stack == stack && p.matches(it) {
+			members = append(members, it)
+			all = all && p.selected[it.ID()]
+		}
+	}
+	for _, it := range members {
+		if all {
+			delete(p.selected, it.ID())
+		} else {
+			p.selected[it.ID()] = true
+		}
+	}
+}
+
+// View renders the picker for a terminal of the given size. Lines end in
+// \r\n because the terminal is in raw mode.
+func (p *Picker) View(width, height int) string {
+	rows := p.rows()
+	if p.cursor >= len(rows) {
+		p.cursor = max(len(rows)-1, 0)
+	}
+
+	listH := max(height-4, 1)
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+listH {
+		p.offset = p.cursor - listH + 1
+	}
+
+	leftW := min(max(width*2/5, 30), width)
+	rightW := max(width-leftW-3, 0)
+
+	var preview []string
+	if rightW > 0 && p.cursor < len(rows) {
+		preview = p.previewLines(rows[p.cursor], rightW)
+		p.previewTop = min(p.previewTop, max(len(preview)-listH, 0))
+	}
+
+	var b strings.Builder
+	status := fmt.Sprintf("%d selected", len(p.selected))
+	search := "Search: " + string(p.query) + "▏"
+	b.WriteString(ansiBold + pad(search, width-utf8.RuneCountInString(status)) + ansiReset + status + "\r\n")
+	b.WriteString(ansiDim + strings.Repeat("─", width) + ansiReset + "\r\n")
+
+	for line := 0; line < listH; line++ {
+		left := ""
+		if i := p.offset + line; i < len(rows) {
+			left = p.renderRow(rows[i], leftW, i == p.cursor)
+		} else {
+			left = strings.Repeat(" ", leftW)
+		}
+		b.WriteString(left)
+		if rightW > 0 {
+			text := ""
+			if j := p.previewTop + line; j < len(preview) {
+				text = preview[j]
+			}
+			b.WriteString(ansiDim + " │ " + ansiReset + truncate(text, rightW))
+		}
+		b.WriteString("\x1b[K\r\n")
+	}
+	if len(rows) == 0 {
+		b.WriteString("No skills match.\x1b[K\r\n")
+	}
+
+	b.WriteString(ansiDim + strings.Repeat("─", width) + ansiReset + "\r\n")
+	b.WriteString(ansiDim + truncate("↑↓ move  space select  ←→ fold  pgup/pgdn preview  enter confirm  esc cancel", width) + ansiReset)
+	return b.String()
+}
+
+// renderRow draws a stack header or a skill padded to width
+func (p *Picker) renderRow(r row, width int, current bool) string {
+	var text, badges string
+	if r.item == nil {
+		marker := "▾"
+		if p.collapsed[r.stack] && len(p.query) == 0 {
+			marker = "▸"
+		}
+		text = fmt.Sprintf("%s %s (%d)", marker, strings.ToUpper(r.stack), r.count)
+	} else {
+		check := "[ ]"
+		if p.selected[r.item.ID()] {
+			check = "[x]"
+		}
+		text = "  " + check + " " + r.item.Name
+		switch {
+		case r.item.Outdated:
+			badges = " outdated"
+		case r.item.Installed:
+			badges = " installed"
+		}
+	}
+
+	nameW := max(width-utf8.RuneCountInString(badges), 0)
+	line := pad(truncate(text, nameW), nameW)
+	switch {
+	case current:
+		return ansiReverse + line + badges + ansiReset
+	case r.item == nil:
+		return ansiBold + line + ansiReset
+	case r.item.Outdated:
+		return line + ansiYellow + badges + ansiReset
+	default:
+		return line + ansiGreen + badges + ansiReset
+	}
+}
+
+// previewLines wraps the preview of the row under the cursor
+func (p *Picker) previewLines(r row, width int) []string {
+	var text string
+	if r.item == nil {
+		text = fmt.Sprintf("%s: %d skill(s)\n\nspace selects every skill of the stack, ← and → fold it.", strings.ToUpper(r.stack), r.count)
+	} else {
+		text = r.item.Description
+		if p.preview != nil {
+			text = p.preview(*r.item)
+		}
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\t", "    "), "\n") {
+		lines = append(lines, wrap(line, width)...)
+	}
+	return lines
+}
+
+// wrap breaks a line at spaces to fit width, hard-breaking long words
+func wrap(line string, width int) []string {
+	if width <= 0 || utf8.RuneCountInString(line) <= width {
+		return []string{line}
+	}
+	var lines []string
+	runes := []rune(line)
+	for len(runes) > width {
+		cut := width
+		for i := width; i > width/2; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		lines = append(lines, string(runes[:cut]))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(lines, string(runes))
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string([]rune(s)[:max(width, 0)])
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
+
+func pad(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"unicode/utf8"
+)
+
+var (
+	// ErrNoTerminal is returned by Run when the terminal cannot be put in
+	// raw mode; callers fall back to plain prompts
+	ErrNoTerminal = errors.New("interactive terminal not available")
+
+	// ErrCanceled is returned by Run when the picker is closed without confirming
+	ErrCanceled = errors.New("canceled")
+)
+
+// Keys the picker understands
+const (
+	keyRune = iota
+	keyEnter
+	keyCancel
+	keyUp
+	keyDown
+	keyLeft
+	keyRight
+	keyHome
+	keyEnd
+	keyPageUp
+	keyPageDown
+	keyToggle
+	keyBackspace
+	keyClear
+)
+
+type key struct {
+	code int
+	r    rune
+}
+
+// Run shows the picker full screen until it is confirmed or canceled, and
+// returns the selected items
+func (p *Picker) Run() ([]Item, error) {
+	restore, err := enableRaw()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNoTerminal, err)
+	}
+	defer restore()
+
+	// Alternate screen, hidden cursor
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	buf := make([]byte, 256)
+	for {
+		rows, cols := size()
+		fmt.Print("\x1b[H" + p.View(cols, rows) + "\x1b[J")
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range parseKeys(buf[:n]) {
+			if done, confirmed := p.handle(k); done {
+				if !confirmed {
+					return nil, ErrCanceled
+				}
+				return p.Selected(), nil
+			}
+		}
+	}
+}
+
+// parseKeys decodes the bytes of one terminal read into keys
+func parseKeys(b []byte) []key {
+	var keys []key
+	for len(b) > 0 {
+		switch {
+		case b[0] == 0x1b && len(b) == 1:
+			keys = append(keys, key{code: keyCancel})
+			b = b[1:]
+		case b[0] == 0x1b && len(b) >= 3 && (b[1] == '[' || b[1] == 'O'):
+			k, n := parseEscape(b)
+			if k >= 0 {
+				keys = append(keys, key{code: k})
+			}
+			b = b[n:]
+		case b[0] == 0x1b:
+			// Alt+key or an unknown sequence: skip the escape
+			b = b[1:]
+		case b[0] == '\r' || b[0] == '\n':
+			keys = append(keys, key{code: keyEnter})
+			b = b[1:]
+		case b[0] == 0x03: // Ctrl-C
+			keys = append(keys, key{code: keyCancel})
+			b = b[1:]
+		case b[0] == 0x7f || b[0] == 0x08:
+			keys = append(keys, key{code: keyBackspace})
+			b = b[1:]
+		case b[0] == 0x15: // Ctrl-U
+			keys = append(keys, key{code: keyClear})
+			b = b[1:]
+		case b[0] == ' ' || b[0] == '\t':
+			keys = append(keys, key{code: keyToggle})
+			b = b[1:]
+		case b[0] == 0x10: // Ctrl-P
+			keys = append(keys, key{code: keyUp})
+			b = b[1:]
+		case b[0] == 0x0e: // Ctrl-N
+			keys = append(keys, key{code: keyDown})
+			b = b[1:]
+		case b[0] < 0x20:
+			b = b[1:]
+		default:
+			r, n := utf8.DecodeRune(b)
+			if r != utf8.RuneError {
+				keys = append(keys, key{code: keyRune, r: r})
+			}
+			b = b[n:]
+		}
+	}
+	return keys
+}
+
+// parseEscape decodes a CSI or SS3 sequence, returning -1 for unknown ones
+// and the number of bytes consumed
+func parseEscape(b []byte) (int, int) {
+	// Sequences end at the first byte in 0x40-0x7e after the introducer
+	end := 2
+	for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+		end++
+	}
+	if end == len(b) {
+		return -1, len(b)
+	}
+
+	switch string(b[2 : end+1]) {
+	case "A":
+		return keyUp, end + 1
+	case "B":
+		return keyDown, end + 1
+	case "C":
+		return keyRight, end + 1
+	case "D":
+		return keyLeft, end + 1
+	case "H", "1~", "7~":
+		return keyHome, end + 1
+	case "F", "4~", "8~":
+		return keyEnd, end + 1
+	case "5~":
+		return keyPageUp, end + 1
+	case "6~":
+		return keyPageDown, end + 1
+	}
+	return -1, end + 1
+}
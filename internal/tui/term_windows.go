@@ -0,0 +1,13 @@
+package tui
+
+import "errors"
+
+// enableRaw is not implemented on Windows; callers fall back to prompts
+func enableRaw() (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported on Windows")
+}
+
+// size returns the default terminal size
+func size() (rows, cols int) {
+	return 24, 80
+}
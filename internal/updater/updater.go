@@ -29,9 +29,21 @@ type Release struct {
 
 type Asset struct {
 	Name               string `json:"name"`
+	Size               int64  `json:"size"`
 	BrowserDownloadURL string `json:"browser_download_url"`
 }
 
+// UpdatePlan describes what SelfUpdate would do
+type UpdatePlan struct {
+	CurrentVersion string `json:"current_version"`
+	LatestVersion  string `json:"latest_version"`
+	HasUpdate      bool   `json:"has_update"`
+	Asset          string `json:"asset,omitempty"`
+	Size           int64  `json:"size,omitempty"` // Download size in bytes
+	URL            string `json:"url,omitempty"`
+	Executable     string `json:"executable,omitempty"` // Binary that would be replaced
+}
+
 func CheckForUpdate() (string, bool, error) {
 	release, err := getLatestRelease()
 	if err != nil {
@@ -52,25 +64,46 @@ func CheckForUpdate() (string, bool, error) {
 	return currentVersion, false, nil
 }
 
-func SelfUpdate() error {
+// Plan resolves the latest release and the asset for this platform without
+// downloading anything
+func Plan() (*UpdatePlan, error) {
 	release, err := getLatestRelease()
 	if err != nil {
-		return fmt.Errorf("failed to get latest release: %w", err)
+		return nil, fmt.Errorf("failed to get latest release: %w", err)
 	}
 
-	assetName := getAssetName()
-	var downloadURL string
+	plan := &UpdatePlan{
+		CurrentVersion: version.GetVersion(),
+		LatestVersion:  strings.TrimPrefix(release.TagName, "v"),
+	}
+	plan.HasUpdate = plan.CurrentVersion == "dev" || plan.LatestVersion != plan.CurrentVersion
+	if !plan.HasUpdate {
+		return plan, nil
+	}
 
-	for _, asset := range release.Assets {
-		if asset.Name == assetName {
-			downloadURL = asset.BrowserDownloadURL
-			break
-		}
+	asset, ok := findAsset(release)
+	if !ok {
+		return nil, fmt.Errorf("no suitable binary found for %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
+	plan.Asset, plan.Size, plan.URL = asset.Name, asset.Size, asset.BrowserDownloadURL
+
+	if plan.Executable, err = os.Executable(); err != nil {
+		return nil, fmt.Errorf("failed to get executable path: %w", err)
+	}
+	return plan, nil
+}
 
-	if downloadURL == "" {
+func SelfUpdate() error {
+	release, err := getLatestRelease()
+	if err != nil {
+		return fmt.Errorf("failed to get latest release: %w", err)
+	}
+
+	asset, ok := findAsset(release)
+	if !ok {
 		return fmt.Errorf("no suitable binary found for %s/%s", runtime.GOOS, runtime.GOARCH)
 	}
+	downloadURL := asset.BrowserDownloadURL
 
 	// Download the archive
 	resp, err := http.Get(downloadURL)
@@ -136,6 +169,17 @@ func SelfUpdate() error {
 	return nil
 }
 
+// findAsset returns the release asset for the current platform
+func findAsset(release *Release) (Asset, bool) {
+	assetName := getAssetName()
+	for _, asset := range release.Assets {
+		if asset.Name == assetName {
+			return asset, true
+		}
+	}
+	return Asset{}, false
+}
+
 // extractFromTarGz extracts a specific file from a tar.gz archive
 func extractFromTarGz(r io.Reader, filename string) ([]byte, error) {
 	gzr, err := gzip.NewReader(r)